@@ -0,0 +1,165 @@
+// Package animation plays clips made of named frames. It doesn't know how frames are painted, so
+// any game object can use it with atlas sprites, separate textures or anything else.
+package animation
+
+import (
+	"fmt"
+	"time"
+)
+
+// Mode defines what a clip does after its last frame
+type Mode string
+
+// Modes of clips
+const (
+	// Loop starts the clip over
+	Loop Mode = "loop"
+	// Once stops at the last frame
+	Once Mode = "once"
+	// PingPong plays the clip forwards, then backwards, and so on
+	PingPong Mode = "pingpong"
+)
+
+// Frame is a single frame of a clip
+type Frame struct {
+	Name string `json:"frame"`
+
+	// Duration of the frame in milliseconds
+	Duration int `json:"duration"`
+}
+
+// Clip is a sequence of frames
+type Clip struct {
+	Mode   Mode    `json:"mode"`
+	Frames []Frame `json:"frames"`
+}
+
+// Validate checks that the clip can be played
+func (c Clip) Validate() error {
+	switch c.Mode {
+	case Loop, Once, PingPong:
+	default:
+		return fmt.Errorf("unknown mode %q", c.Mode)
+	}
+
+	if len(c.Frames) == 0 {
+		return fmt.Errorf("clip has no frames")
+	}
+
+	for i, f := range c.Frames {
+		if f.Duration <= 0 {
+			return fmt.Errorf("frame %d must have positive duration, got %d", i+1, f.Duration)
+		}
+	}
+
+	return nil
+}
+
+// cycle returns frames of a single cycle of the clip
+func (c Clip) cycle() []Frame {
+	if c.Mode != PingPong || len(c.Frames) < 3 {
+		return c.Frames
+	}
+
+	frames := append([]Frame{}, c.Frames...)
+	for i := len(c.Frames) - 2; i > 0; i-- {
+		frames = append(frames, c.Frames[i])
+	}
+	return frames
+}
+
+// Player plays one of its clips at a time. Time of the player advances only by Update, so the
+// animation stops when the game does.
+type Player struct {
+	clips map[string]Clip
+
+	name    string
+	frames  []Frame
+	mode    Mode
+	length  time.Duration
+	elapsed time.Duration
+}
+
+// NewPlayer creates new Player of clips. It starts playing the clip called start.
+func NewPlayer(clips map[string]Clip, start string) (*Player, error) {
+	for name, c := range clips {
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("clip %s: %v", name, err)
+		}
+	}
+
+	p := &Player{clips: clips}
+	if err := p.Play(start); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Has reports whether the player has the clip
+func (p *Player) Has(name string) bool {
+	_, ok := p.clips[name]
+	return ok
+}
+
+// Play switches to the clip. If the clip is already playing, it just continues.
+func (p *Player) Play(name string) error {
+	if name == p.name {
+		return nil
+	}
+
+	c, ok := p.clips[name]
+	if !ok {
+		return fmt.Errorf("unknown clip %s", name)
+	}
+
+	p.name = name
+	p.mode = c.Mode
+	p.frames = c.cycle()
+	p.length = 0
+	for _, f := range p.frames {
+		p.length += time.Duration(f.Duration) * time.Millisecond
+	}
+	p.elapsed = 0
+
+	return nil
+}
+
+// Restart plays the current clip from its first frame
+func (p *Player) Restart() {
+	p.elapsed = 0
+}
+
+// Clip returns name of the current clip
+func (p *Player) Clip() string {
+	return p.name
+}
+
+// Update advances the animation by dt
+func (p *Player) Update(dt time.Duration) {
+	p.elapsed += dt
+	if p.mode == Once {
+		if p.elapsed > p.length {
+			p.elapsed = p.length
+		}
+		return
+	}
+	p.elapsed %= p.length
+}
+
+// Finished reports whether a clip played once has reached its end. Looped clips never finish.
+func (p *Player) Finished() bool {
+	return p.mode == Once && p.elapsed >= p.length
+}
+
+// Frame returns name of the frame to show at the moment
+func (p *Player) Frame() string {
+	t := p.elapsed
+	for _, f := range p.frames {
+		d := time.Duration(f.Duration) * time.Millisecond
+		if t < d {
+			return f.Name
+		}
+		t -= d
+	}
+	return p.frames[len(p.frames)-1].Name
+}
@@ -0,0 +1,120 @@
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+func clip(mode Mode, names ...string) Clip {
+	c := Clip{Mode: mode}
+	for _, name := range names {
+		c.Frames = append(c.Frames, Frame{Name: name, Duration: 100})
+	}
+	return c
+}
+
+func TestClipValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		clip Clip
+		ok   bool
+	}{
+		{"loop", clip(Loop, "a", "b"), true},
+		{"single frame", clip(Once, "a"), true},
+		{"unknown mode", clip("reverse", "a"), false},
+		{"no frames", clip(Loop), false},
+		{"zero duration", Clip{Mode: Loop, Frames: []Frame{{Name: "a"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.clip.Validate()
+			if tt.ok && err != nil {
+				t.Errorf("Validate() error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("Validate() has accepted invalid clip")
+			}
+		})
+	}
+}
+
+func TestPlayerFrames(t *testing.T) {
+	tests := []struct {
+		name   string
+		clip   Clip
+		frames string
+	}{
+		{"loop", clip(Loop, "a", "b", "c"), "abcabcab"},
+		{"once", clip(Once, "a", "b", "c"), "abcccccc"},
+		{"pingpong", clip(PingPong, "a", "b", "c"), "abcbabcb"},
+		{"pingpong of two", clip(PingPong, "a", "b"), "abababab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPlayer(map[string]Clip{"clip": tt.clip}, "clip")
+			if err != nil {
+				t.Fatalf("NewPlayer() error: %v", err)
+			}
+
+			var frames string
+			for range tt.frames {
+				frames += p.Frame()
+				p.Update(100 * time.Millisecond)
+			}
+			if frames != tt.frames {
+				t.Errorf("frames are %s, want %s", frames, tt.frames)
+			}
+		})
+	}
+}
+
+func TestPlayerFinished(t *testing.T) {
+	clips := map[string]Clip{"fly": clip(Loop, "a", "b"), "fall": clip(Once, "c", "d")}
+	p, err := NewPlayer(clips, "fly")
+	if err != nil {
+		t.Fatalf("NewPlayer() error: %v", err)
+	}
+
+	p.Update(time.Second)
+	if p.Finished() {
+		t.Errorf("looped clip has finished")
+	}
+
+	if err := p.Play("fall"); err != nil {
+		t.Fatalf("Play() error: %v", err)
+	}
+	if p.Clip() != "fall" || p.Frame() != "c" {
+		t.Errorf("player shows %s of %s, want c of fall", p.Frame(), p.Clip())
+	}
+	p.Update(150 * time.Millisecond)
+	if p.Finished() {
+		t.Errorf("clip has finished in the middle")
+	}
+
+	// playing the current clip continues it
+	p.Play("fall")
+	p.Update(50 * time.Millisecond)
+	if !p.Finished() || p.Frame() != "d" {
+		t.Errorf("player shows %s, finished %v, want finished at d", p.Frame(), p.Finished())
+	}
+
+	p.Restart()
+	if p.Finished() || p.Frame() != "c" {
+		t.Errorf("restarted player shows %s, finished %v, want c", p.Frame(), p.Finished())
+	}
+
+	if err := p.Play("jump"); err == nil {
+		t.Errorf("Play() has accepted unknown clip")
+	}
+}
+
+func TestNewPlayerInvalid(t *testing.T) {
+	if _, err := NewPlayer(map[string]Clip{"fly": clip(Loop)}, "fly"); err == nil {
+		t.Errorf("NewPlayer() has accepted clip without frames")
+	}
+	if _, err := NewPlayer(map[string]Clip{"fly": clip(Loop, "a")}, "fall"); err == nil {
+		t.Errorf("NewPlayer() has accepted unknown start clip")
+	}
+}
@@ -0,0 +1,222 @@
+// Package audio plays sound effects and background music using SDL_mixer. When there is no
+// audio device, the game stays silent instead of failing.
+package audio
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spoof/go-flappybird/res"
+	"github.com/veandco/go-sdl2/mix"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Sound is a short sound effect
+type Sound string
+
+// Sound effects of the game
+const (
+	Flap  Sound = "flap"
+	Score Sound = "score"
+	Hit   Sound = "hit"
+	Fall  Sound = "fall"
+)
+
+var sounds = []Sound{Flap, Score, Hit, Fall}
+
+// Music is a background track looped while a scene is shown
+type Music string
+
+// Background tracks of the game
+const (
+	MenuMusic Music = "menu"
+	GameMusic Music = "game"
+)
+
+var tracks = []Music{MenuMusic, GameMusic}
+
+const (
+	frequency = 44100
+	chunkSize = 1024
+)
+
+// Audio plays sounds and music. Like the rest of SDL calls, methods are called from the main
+// thread. If audio device couldn't be opened, the methods do nothing.
+type Audio struct {
+	enabled bool
+	sounds  map[Sound]*mix.Chunk
+	music   map[Music]*mix.Music
+	playing Music
+
+	master      float64
+	musicVolume float64
+	sfxVolume   float64
+	muted       bool
+}
+
+// Silent returns Audio which doesn't open audio device and plays nothing, e.g. for scenes
+// rendered headlessly
+func Silent() *Audio {
+	return &Audio{master: 1, musicVolume: 1, sfxVolume: 1}
+}
+
+// Open opens audio device and loads sounds and music of the game. Missing audio device isn't an
+// error: the returned Audio is silent then.
+func Open() (*Audio, error) {
+	a := Silent()
+
+	if err := sdl.InitSubSystem(sdl.INIT_AUDIO); err != nil {
+		log.Printf("audio is disabled: could not initialize audio: %v", err)
+		return a, nil
+	}
+
+	if err := mix.OpenAudio(frequency, mix.DEFAULT_FORMAT, mix.DEFAULT_CHANNELS, chunkSize); err != nil {
+		log.Printf("audio is disabled: could not open audio device: %v", err)
+		sdl.QuitSubSystem(sdl.INIT_AUDIO)
+		return a, nil
+	}
+	a.enabled = true
+
+	a.sounds = make(map[Sound]*mix.Chunk)
+	for _, s := range sounds {
+		chunk, err := loadSound(s)
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("could not load %s sound: %v", s, err)
+		}
+		a.sounds[s] = chunk
+	}
+
+	a.music = make(map[Music]*mix.Music)
+	for _, m := range tracks {
+		mus, err := loadMusic(m)
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("could not load %s music: %v", m, err)
+		}
+		a.music[m] = mus
+	}
+
+	return a, nil
+}
+
+// Play plays sound effect once
+func (a *Audio) Play(s Sound) {
+	if !a.enabled {
+		return
+	}
+
+	// all channels may be busy, losing a sound effect is fine then
+	a.sounds[s].Play(-1, 0)
+}
+
+// PlayMusic loops the track. If the track is already playing, it just continues.
+func (a *Audio) PlayMusic(m Music) {
+	if !a.enabled {
+		return
+	}
+
+	if a.playing == m && mix.PlayingMusic() {
+		mix.ResumeMusic()
+		return
+	}
+
+	if err := a.music[m].Play(-1); err != nil {
+		log.Printf("could not play %s music: %v", m, err)
+		return
+	}
+	a.playing = m
+}
+
+// PauseMusic pauses the current track until PlayMusic is called with it again
+func (a *Audio) PauseMusic() {
+	if a.enabled {
+		mix.PauseMusic()
+	}
+}
+
+// SetVolume sets master, music and sound effects volumes. Volumes are in range 0..1, music and
+// sound effects volumes are relative to the master one.
+func (a *Audio) SetVolume(master, music, sfx float64) {
+	a.master, a.musicVolume, a.sfxVolume = master, music, sfx
+	a.applyVolume()
+}
+
+// SetMuted mutes or unmutes all sounds
+func (a *Audio) SetMuted(muted bool) {
+	a.muted = muted
+	a.applyVolume()
+}
+
+// Muted reports whether sounds are muted
+func (a *Audio) Muted() bool {
+	return a.muted
+}
+
+// ToggleMute mutes sounds if they are playing and unmutes them otherwise. It returns whether
+// sounds are muted now.
+func (a *Audio) ToggleMute() bool {
+	a.muted = !a.muted
+	a.applyVolume()
+	return a.muted
+}
+
+// Close stops playing and frees all sounds
+func (a *Audio) Close() {
+	if !a.enabled {
+		return
+	}
+	a.enabled = false
+
+	mix.HaltMusic()
+	mix.HaltChannel(-1)
+	for _, chunk := range a.sounds {
+		chunk.Free()
+	}
+	for _, mus := range a.music {
+		mus.Free()
+	}
+
+	mix.CloseAudio()
+	sdl.QuitSubSystem(sdl.INIT_AUDIO)
+}
+
+func loadSound(s Sound) (*mix.Chunk, error) {
+	rw, err := res.RWops("sounds/" + string(s) + ".wav")
+	if err != nil {
+		return nil, err
+	}
+	return mix.LoadWAVRW(rw, true)
+}
+
+func loadMusic(m Music) (*mix.Music, error) {
+	rw, err := res.RWops("music/" + string(m) + ".wav")
+	if err != nil {
+		return nil, err
+	}
+	return mix.LoadMUSRW(rw, 1)
+}
+
+func (a *Audio) applyVolume() {
+	if !a.enabled {
+		return
+	}
+
+	master := a.master
+	if a.muted {
+		master = 0
+	}
+
+	mix.VolumeMusic(toMixVolume(master * a.musicVolume))
+	mix.Volume(-1, toMixVolume(master*a.sfxVolume))
+}
+
+func toMixVolume(v float64) int {
+	if v < 0 {
+		v = 0
+	}
+	if v > 1 {
+		v = 1
+	}
+	return int(v * mix.MAX_VOLUME)
+}
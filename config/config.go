@@ -0,0 +1,251 @@
+// Package config holds tunables of the game. They are read from a JSON file and can be
+// overridden by command line flags.
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spoof/go-flappybird/world"
+)
+
+// Scaling modes of the logical screen
+const (
+	// ScalingSmooth scales the screen by any factor with linear filtering
+	ScalingSmooth = "smooth"
+	// ScalingPixel scales the screen by any factor keeping pixels sharp
+	ScalingPixel = "pixel"
+	// ScalingInteger scales the screen by whole factors only, so all pixels are equal
+	ScalingInteger = "integer"
+)
+
+// Transitions between scenes
+const (
+	TransitionNone  = "none"
+	TransitionFade  = "fade"
+	TransitionSlide = "slide"
+	TransitionIris  = "iris"
+)
+
+// Difficulties of the game
+const (
+	DifficultyEasy   = "easy"
+	DifficultyNormal = "normal"
+	DifficultyHard   = "hard"
+)
+
+// Difficulties lists difficulties from the easiest one
+var Difficulties = []string{DifficultyEasy, DifficultyNormal, DifficultyHard}
+
+const (
+	minWindowWidth  = 320
+	minWindowHeight = 240
+)
+
+// Config is the configuration of the game. The game is rendered at logical resolution of
+// WindowWidth x WindowHeight and scaled to the actual size of the window.
+type Config struct {
+	WindowWidth  int           `json:"window_width"`
+	WindowHeight int           `json:"window_height"`
+	Fullscreen   bool          `json:"fullscreen"`
+	Scaling      string        `json:"scaling"`
+	Theme        string        `json:"theme"`
+	Difficulty   string        `json:"difficulty"`
+	BirdX        int           `json:"bird_x"`
+	Physics      world.Physics `json:"physics"`
+	Audio        Audio         `json:"audio"`
+	Transition   Transition    `json:"transition"`
+}
+
+// Audio holds volumes of the game in range 0..1. Music and SFX volumes are relative to the
+// master one.
+type Audio struct {
+	Master float64 `json:"master"`
+	Music  float64 `json:"music"`
+	SFX    float64 `json:"sfx"`
+	Muted  bool    `json:"muted"`
+}
+
+// Transition is animation played when scenes are switched
+type Transition struct {
+	Kind string `json:"kind"`
+
+	// Duration of the animation in milliseconds
+	Duration int `json:"duration"`
+}
+
+// Default returns configuration of the classic game
+func Default() Config {
+	return Config{
+		WindowWidth:  800,
+		WindowHeight: 600,
+		Scaling:      ScalingSmooth,
+		Difficulty:   DifficultyNormal,
+		BirdX:        200,
+		Physics:      world.DefaultPhysics(),
+		Audio: Audio{
+			Master: 1,
+			Music:  0.6,
+			SFX:    1,
+		},
+		Transition: Transition{
+			Kind:     TransitionFade,
+			Duration: 300,
+		},
+	}
+}
+
+// SetDifficulty sets speed of pipes and gaps between them according to the difficulty. The rest
+// of physics is left untouched.
+func (c *Config) SetDifficulty(difficulty string) error {
+	p := world.DefaultPhysics()
+	switch difficulty {
+	case DifficultyEasy:
+		p.ScrollSpeed = 160
+		p.DistanceBetweenPipes = 340
+		p.SpaceBetweenPipes = 200
+	case DifficultyNormal:
+	case DifficultyHard:
+		p.ScrollSpeed = 250
+		p.DistanceBetweenPipes = 270
+		p.SpaceBetweenPipes = 130
+	default:
+		return fmt.Errorf("unknown difficulty %q", difficulty)
+	}
+
+	c.Difficulty = difficulty
+	c.Physics.ScrollSpeed = p.ScrollSpeed
+	c.Physics.DistanceBetweenPipes = p.DistanceBetweenPipes
+	c.Physics.SpaceBetweenPipes = p.SpaceBetweenPipes
+	return nil
+}
+
+// Load reads configuration from the file on top of c. Values missing in the file are left
+// untouched. Difficulty of the file sets physics the file doesn't give explicitly.
+func (c *Config) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file struct {
+		Difficulty *string `json:"difficulty"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("could not decode config: %v", err)
+	}
+	if file.Difficulty != nil {
+		if err := c.SetDifficulty(*file.Difficulty); err != nil {
+			return err
+		}
+	}
+
+	// physics of the file overrides the preset, so it's decoded after the preset is applied
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("could not decode config: %v", err)
+	}
+
+	return nil
+}
+
+// Save writes configuration to the file
+func (c Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode config: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory: %v", err)
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// RegisterFlags defines flags which override fields of c
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.WindowWidth, "width", c.WindowWidth, "window width")
+	fs.IntVar(&c.WindowHeight, "height", c.WindowHeight, "window height")
+	fs.BoolVar(&c.Fullscreen, "fullscreen", c.Fullscreen, "start in fullscreen mode")
+	fs.StringVar(&c.Scaling, "scaling", c.Scaling, "scaling of the window: smooth, pixel or integer")
+	fs.StringVar(&c.Theme, "theme", c.Theme, "directory or zip archive with a theme; built-in theme if not set")
+	fs.IntVar(&c.BirdX, "bird-x", c.BirdX, "horizontal position of the bird")
+	fs.StringVar(&c.Difficulty, "difficulty", c.Difficulty, "difficulty: easy, normal or hard; sets speed of pipes and gaps between them")
+
+	p := &c.Physics
+	fs.IntVar(&p.TickRate, "tickrate", p.TickRate, "number of simulation ticks per second")
+	fs.Float64Var(&p.Gravity, "gravity", p.Gravity, "gravity, px/s²")
+	fs.Float64Var(&p.JumpSpeed, "jump-speed", p.JumpSpeed, "vertical speed of the bird after a flap, px/s")
+	fs.Float64Var(&p.FallSpeed, "fall-speed", p.FallSpeed, "speed of the bird falling after a crash, px/s")
+	fs.Float64Var(&p.ScrollSpeed, "scroll-speed", p.ScrollSpeed, "speed of pipes, px/s")
+	fs.IntVar(&p.DistanceBetweenPipes, "pipe-distance", p.DistanceBetweenPipes, "horizontal distance between pipes, px")
+	fs.IntVar(&p.SpaceBetweenPipes, "pipe-gap", p.SpaceBetweenPipes, "vertical gap between upper and lower pipes, px")
+	fs.IntVar(&p.MinPipeHeight, "pipe-min-height", p.MinPipeHeight, "minimal height of a pipe, px")
+
+	a := &c.Audio
+	fs.Float64Var(&a.Master, "volume", a.Master, "master volume, 0..1")
+	fs.Float64Var(&a.Music, "music-volume", a.Music, "music volume relative to the master one, 0..1")
+	fs.Float64Var(&a.SFX, "sfx-volume", a.SFX, "sound effects volume relative to the master one, 0..1")
+	fs.BoolVar(&a.Muted, "mute", a.Muted, "start with sound muted")
+
+	t := &c.Transition
+	fs.StringVar(&t.Kind, "transition", t.Kind, "transition between scenes: none, fade, slide or iris")
+	fs.IntVar(&t.Duration, "transition-duration", t.Duration, "duration of transitions between scenes, ms")
+}
+
+// Validate checks that the game is playable with the configuration
+func (c Config) Validate() error {
+	if c.WindowWidth < minWindowWidth || c.WindowHeight < minWindowHeight {
+		return fmt.Errorf("window must be at least %dx%d, got %dx%d",
+			minWindowWidth, minWindowHeight, c.WindowWidth, c.WindowHeight)
+	}
+
+	switch c.Scaling {
+	case ScalingSmooth, ScalingPixel, ScalingInteger:
+	default:
+		return fmt.Errorf("unknown scaling %q", c.Scaling)
+	}
+
+	switch c.Transition.Kind {
+	case TransitionNone, TransitionFade, TransitionSlide, TransitionIris:
+	default:
+		return fmt.Errorf("unknown transition %q", c.Transition.Kind)
+	}
+
+	if c.Transition.Duration < 0 {
+		return fmt.Errorf("transition duration can't be negative, got %d", c.Transition.Duration)
+	}
+
+	switch c.Difficulty {
+	case DifficultyEasy, DifficultyNormal, DifficultyHard:
+	default:
+		return fmt.Errorf("unknown difficulty %q", c.Difficulty)
+	}
+
+	if c.BirdX <= 0 || c.BirdX >= c.WindowWidth {
+		return fmt.Errorf("bird x must be inside the window, got %d", c.BirdX)
+	}
+
+	if err := c.Physics.Validate(c.WindowHeight); err != nil {
+		return err
+	}
+
+	volumes := []struct {
+		name  string
+		value float64
+	}{
+		{"master volume", c.Audio.Master},
+		{"music volume", c.Audio.Music},
+		{"sound effects volume", c.Audio.SFX},
+	}
+	for _, v := range volumes {
+		if v.value < 0 || v.value > 1 {
+			return fmt.Errorf("%s must be in range 0..1, got %v", v.name, v.value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(c *Config)
+		ok   bool
+	}{
+		{"default", func(c *Config) {}, true},
+		{"small window", func(c *Config) { c.WindowWidth = 100 }, false},
+		{"unknown scaling", func(c *Config) { c.Scaling = "huge" }, false},
+		{"unknown transition", func(c *Config) { c.Transition.Kind = "spin" }, false},
+		{"negative transition", func(c *Config) { c.Transition.Duration = -1 }, false},
+		{"unknown difficulty", func(c *Config) { c.Difficulty = "insane" }, false},
+		{"bird outside window", func(c *Config) { c.BirdX = c.WindowWidth }, false},
+		{"zero tick rate", func(c *Config) { c.Physics.TickRate = 0 }, false},
+		{"pipes don't fit", func(c *Config) { c.Physics.MinPipeHeight = c.WindowHeight / 2 }, false},
+		{"loud music", func(c *Config) { c.Audio.Music = 1.5 }, false},
+		{"no transition", func(c *Config) { c.Transition = Transition{Kind: TransitionNone} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			tt.edit(&c)
+
+			err := c.Validate()
+			if tt.ok && err != nil {
+				t.Errorf("Validate() error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("Validate() has accepted invalid config")
+			}
+		})
+	}
+}
+
+func TestConfigSetDifficulty(t *testing.T) {
+	for _, d := range Difficulties {
+		t.Run(d, func(t *testing.T) {
+			c := Default()
+			c.Physics.Gravity = 1234
+			if err := c.SetDifficulty(d); err != nil {
+				t.Fatalf("SetDifficulty() error: %v", err)
+			}
+
+			if c.Difficulty != d {
+				t.Errorf("difficulty is %q, want %q", c.Difficulty, d)
+			}
+			if c.Physics.Gravity != 1234 {
+				t.Errorf("gravity is %v, want it untouched", c.Physics.Gravity)
+			}
+			if err := c.Validate(); err != nil {
+				t.Errorf("config is invalid: %v", err)
+			}
+		})
+	}
+
+	c := Default()
+	if err := c.SetDifficulty("insane"); err == nil {
+		t.Errorf("SetDifficulty() has accepted unknown difficulty")
+	}
+	if !reflect.DeepEqual(c, Default()) {
+		t.Errorf("unknown difficulty has changed config")
+	}
+}
+
+func TestConfigDifficultyOrder(t *testing.T) {
+	var prev *Config
+	for _, d := range Difficulties {
+		c := Default()
+		if err := c.SetDifficulty(d); err != nil {
+			t.Fatalf("SetDifficulty(%q) error: %v", d, err)
+		}
+		if prev != nil && (c.Physics.ScrollSpeed <= prev.Physics.ScrollSpeed ||
+			c.Physics.SpaceBetweenPipes >= prev.Physics.SpaceBetweenPipes) {
+			t.Errorf("%s isn't harder than %s", d, prev.Difficulty)
+		}
+		prev = &c
+	}
+}
+
+func TestConfigSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "config.json")
+
+	c := Default()
+	c.Fullscreen = true
+	c.Physics.Gravity = 900
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded := Default()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, c) {
+		t.Errorf("Load() = %+v, want %+v", loaded, c)
+	}
+}
+
+func TestConfigLoadPartial(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"physics": {"gravity": 900}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := Default()
+	if err := c.Load(path); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	want := Default()
+	want.Physics.Gravity = 900
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("Load() = %+v, want %+v", c, want)
+	}
+}
+
+func TestConfigLoadDifficulty(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		edit func(c *Config)
+	}{
+		{
+			name: "preset",
+			file: `{"difficulty": "hard"}`,
+			edit: func(c *Config) { c.SetDifficulty(DifficultyHard) },
+		},
+		{
+			name: "explicit physics",
+			file: `{"difficulty": "hard", "physics": {"scroll_speed": 100}}`,
+			edit: func(c *Config) {
+				c.SetDifficulty(DifficultyHard)
+				c.Physics.ScrollSpeed = 100
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(tt.file), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			c := Default()
+			if err := c.Load(path); err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+
+			want := Default()
+			tt.edit(&want)
+			if !reflect.DeepEqual(c, want) {
+				t.Errorf("Load() = %+v, want %+v", c, want)
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"difficulty": "insane"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := Default()
+	if err := c.Load(path); err == nil {
+		t.Errorf("Load() has accepted unknown difficulty")
+	}
+}
+
+func TestConfigFlags(t *testing.T) {
+	c := Default()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-width", "1024", "-gravity", "900", "-difficulty", "hard", "-transition", "iris"}); err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	want := Default()
+	want.WindowWidth = 1024
+	want.Physics.Gravity = 900
+	want.Difficulty = DifficultyHard
+	want.Transition.Kind = TransitionIris
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("config is %+v, want %+v", c, want)
+	}
+}
@@ -0,0 +1,120 @@
+package goldens
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// check compares the frame to its golden image, or writes the golden image with -update. The
+// test fails if the frame doesn't match, then the frame is written next to the golden image
+// with a diff image.
+func check(t *testing.T, f frame) error {
+	path := filepath.Join(*dir, f.name+".png")
+	if *update {
+		if err := os.MkdirAll(*dir, 0755); err != nil {
+			return err
+		}
+		t.Logf("%s: updated", f.name)
+		return writePNG(path, f.img)
+	}
+
+	want, err := readPNG(path)
+	if os.IsNotExist(err) {
+		t.Errorf("no golden image %s, run with -update to create it", path)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if want.Bounds() != f.img.Bounds() {
+		t.Errorf("size %v differs from golden %v", f.img.Bounds().Size(), want.Bounds().Size())
+		return writePNG(suffixed(path, "got"), f.img)
+	}
+
+	n, diff := compare(f.img, want, *tolerance)
+	if float64(n) <= *maxDiff*float64(f.img.Bounds().Dx()*f.img.Bounds().Dy()) {
+		return nil
+	}
+
+	if err := writePNG(suffixed(path, "got"), f.img); err != nil {
+		return err
+	}
+	if err := writePNG(suffixed(path, "diff"), diff); err != nil {
+		return err
+	}
+	t.Errorf("%d pixels differ, see %s", n, suffixed(path, "diff"))
+	return nil
+}
+
+// compare returns number of pixels of got differing from want of the same size and image of
+// the difference. Color channels differing by tolerance or less are equal. Differing pixels are
+// red in the diff image, the rest is faded want.
+func compare(got, want image.Image, tolerance int) (int, *image.RGBA) {
+	b := want.Bounds()
+	diff := image.NewRGBA(b)
+	red := color.RGBA{R: 255, A: 255}
+
+	n := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			g := color.NRGBAModel.Convert(got.At(x, y)).(color.NRGBA)
+			w := color.NRGBAModel.Convert(want.At(x, y)).(color.NRGBA)
+
+			if differ(g.R, w.R, tolerance) || differ(g.G, w.G, tolerance) ||
+				differ(g.B, w.B, tolerance) || differ(g.A, w.A, tolerance) {
+				n++
+				diff.Set(x, y, red)
+				continue
+			}
+
+			gray := color.GrayModel.Convert(w).(color.Gray)
+			diff.Set(x, y, color.RGBA{R: gray.Y / 4, G: gray.Y / 4, B: gray.Y / 4, A: 255})
+		}
+	}
+
+	return n, diff
+}
+
+func differ(a, b uint8, tolerance int) bool {
+	d := int(a) - int(b)
+	return d > tolerance || -d > tolerance
+}
+
+// suffixed returns path with suffix added to the name, e.g. splash.got.png
+func suffixed(path, suffix string) string {
+	return strings.TrimSuffix(path, ".png") + "." + suffix + ".png"
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %s: %v", path, err)
+	}
+	return img, nil
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("could not encode %s: %v", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,13 @@
+// Package goldens renders frames of the game headlessly and compares them to golden images, so
+// changes of rendering don't go unnoticed. Scenes are driven tick by tick with scripted input
+// and drawn by the in-memory renderer, without a window or audio device. A frame differing from
+// its golden image is written next to it with a diff image showing the changed pixels in red.
+//
+// The package has tests only. Check frames from the root of the repository:
+//
+//	go test ./goldens
+//
+// and accept intended changes by writing new golden images:
+//
+//	go test ./goldens -update
+package goldens
@@ -0,0 +1,56 @@
+package goldens
+
+import (
+	"flag"
+	"log"
+	"testing"
+
+	"github.com/spoof/go-flappybird/audio"
+	"github.com/spoof/go-flappybird/config"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+)
+
+var (
+	dir       = flag.String("dir", "frames", "directory with golden images")
+	update    = flag.Bool("update", false, "write rendered frames as new golden images")
+	tolerance = flag.Int("tolerance", 8, "difference of a color channel which is ignored")
+	maxDiff   = flag.Float64("max-diff", 0.001, "part of pixels allowed to differ more than tolerance")
+)
+
+func TestFrames(t *testing.T) {
+	theme, err := res.SetTheme("")
+	if err != nil {
+		t.Fatalf("could not load theme: %v", err)
+	}
+
+	cfg := config.Default()
+	r := render.NewImage(cfg.WindowWidth, cfg.WindowHeight)
+	rm := res.NewManager(r, theme)
+	defer func() {
+		if err := rm.Close(); err != nil {
+			log.Print(err)
+		}
+	}()
+
+	font, err := rm.Font(theme.Fonts.Text, 16)
+	if err != nil {
+		t.Fatalf("could not load fonts, text can't be rendered: %v", err)
+	}
+	font.Release()
+
+	// frames are rendered in memory, nothing is played
+	frames, err := capture(rm, r, cfg, audio.Silent())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range frames {
+		f := f
+		t.Run(f.name, func(t *testing.T) {
+			if err := check(t, f); err != nil {
+				t.Fatalf("could not check %s: %v", f.name, err)
+			}
+		})
+	}
+}
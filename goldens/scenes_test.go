@@ -0,0 +1,136 @@
+package goldens
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+
+	"github.com/spoof/go-flappybird/audio"
+	"github.com/spoof/go-flappybird/config"
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene"
+)
+
+// Script of the captured game: pipes are generated from seed, the bird flaps at ticks of flaps
+// passing three pipes and then falls, which ends the game.
+const (
+	seed = 42
+
+	// minScore is the score the script must reach, so scoring is rendered as well
+	minScore = 3
+
+	// maxTicks stops the game which doesn't end as scripted
+	maxTicks = 10000
+)
+
+var flaps = []int{2, 80, 159, 238, 317, 406, 485, 552, 631}
+
+// gameFrames are ticks of the game frames are captured at
+var gameFrames = []int{1, 60, 200, 500}
+
+// frame is a rendered frame named after the scene and the tick it's captured at
+type frame struct {
+	name string
+	img  *image.RGBA
+}
+
+type recorder struct {
+	r      *render.Image
+	frames []frame
+}
+
+// capture drives scenes created with rm to the scripted ticks and returns their frames
+func capture(rm *res.Manager, r *render.Image, cfg config.Config, a *audio.Audio) ([]frame, error) {
+	rec := &recorder{r: r}
+	w, h := cfg.WindowWidth, cfg.WindowHeight
+	tick := time.Second / time.Duration(cfg.Physics.TickRate)
+
+	splash, err := scene.NewSplash(rm, w, h)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Splash scene: %v", err)
+	}
+	defer splash.Destroy()
+
+	splash.Enter()
+	splash.Update(tick)
+	if err := rec.capture("splash", splash); err != nil {
+		return nil, err
+	}
+
+	game, err := scene.NewGame(rm, w, h, cfg.BirdX, cfg.Physics, a)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Game scene: %v", err)
+	}
+	defer game.Destroy()
+
+	game.SetSeed(seed)
+	game.Enter()
+	var end *scene.EndGameEvent
+	next, flap := 0, 0
+	for i := 1; end == nil; i++ {
+		if i > maxTicks {
+			return nil, fmt.Errorf("game hasn't ended in %d ticks", maxTicks)
+		}
+
+		if flap < len(flaps) && flaps[flap] == i {
+			flap++
+			game.HandleEvent(input.Event{Actions: input.Flap})
+		}
+		end, _ = game.Update(tick).(*scene.EndGameEvent)
+
+		if next < len(gameFrames) && gameFrames[next] == i {
+			next++
+			if err := rec.capture(fmt.Sprintf("game-%04d", i), game); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if next < len(gameFrames) {
+		return nil, fmt.Errorf("game has ended before tick %d", gameFrames[next])
+	}
+	if end.Score < minScore {
+		return nil, fmt.Errorf("game has ended with score %d, want at least %d", end.Score, minScore)
+	}
+
+	gameOver, err := scene.NewGameOver(rm, w, h, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not create Game Over scene: %v", err)
+	}
+	defer gameOver.Destroy()
+
+	gameOver.SetNameEntry(false)
+	gameOver.SetBestScore(end.BestScore)
+	gameOver.SetSeed(end.Seed)
+	gameOver.SetReplay(end.Replay)
+	game.Pause()
+	gameOver.Enter()
+	if err := rec.capture("gameover", game, gameOver); err != nil {
+		return nil, err
+	}
+
+	return rec.frames, nil
+}
+
+// capture renders scenes over each other like the scene manager does and keeps the frame
+func (rec *recorder) capture(name string, scenes ...scene.Scene) error {
+	if err := rec.r.Clear(color.NRGBA{A: 255}); err != nil {
+		return err
+	}
+
+	for _, s := range scenes {
+		if err := s.Render(rec.r); err != nil {
+			return fmt.Errorf("could not render %s: %v", name, err)
+		}
+	}
+
+	src := rec.r.Frame()
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+	rec.frames = append(rec.frames, frame{name: name, img: img})
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Device is a kind of device a binding belongs to
+type Device string
+
+// Devices which can be bound to actions
+const (
+	Keyboard   Device = "key"
+	Mouse      Device = "mouse"
+	Controller Device = "controller"
+)
+
+var mouseButtons = map[string]uint8{
+	"left":   sdl.BUTTON_LEFT,
+	"middle": sdl.BUTTON_MIDDLE,
+	"right":  sdl.BUTTON_RIGHT,
+	"x1":     sdl.BUTTON_X1,
+	"x2":     sdl.BUTTON_X2,
+}
+
+// Binding is a key, mouse button or game controller button. Name is the SDL name of the
+// key or controller button, or one of left, middle, right, x1, x2 for mouse buttons.
+type Binding struct {
+	Device Device `json:"device"`
+	Name   string `json:"name"`
+}
+
+// KeyBinding returns binding of the key
+func KeyBinding(key sdl.Keycode) Binding {
+	return Binding{Device: Keyboard, Name: sdl.GetKeyName(key)}
+}
+
+// MouseBinding returns binding of the mouse button
+func MouseBinding(button uint8) Binding {
+	for name, b := range mouseButtons {
+		if b == button {
+			return Binding{Device: Mouse, Name: name}
+		}
+	}
+	return Binding{Device: Mouse, Name: fmt.Sprintf("button%d", button)}
+}
+
+// ControllerBinding returns binding of the game controller button
+func ControllerBinding(button uint8) Binding {
+	name := sdl.GameControllerGetStringForButton(sdl.GameControllerButton(button))
+	return Binding{Device: Controller, Name: name}
+}
+
+// BindingOf returns binding pressed by the event. It returns false if event isn't a press of
+// a key or button.
+func BindingOf(event sdl.Event) (Binding, bool) {
+	switch e := event.(type) {
+	case *sdl.KeyboardEvent:
+		if e.Type == sdl.KEYDOWN && e.Repeat == 0 {
+			return KeyBinding(e.Keysym.Sym), true
+		}
+	case *sdl.MouseButtonEvent:
+		if e.Type == sdl.MOUSEBUTTONDOWN && e.Which != sdl.TOUCH_MOUSEID {
+			return MouseBinding(e.Button), true
+		}
+	case *sdl.ControllerButtonEvent:
+		if e.Type == sdl.CONTROLLERBUTTONDOWN {
+			return ControllerBinding(e.Button), true
+		}
+	}
+
+	return Binding{}, false
+}
+
+func (b Binding) String() string {
+	switch b.Device {
+	case Mouse:
+		return "Mouse " + b.Name
+	case Controller:
+		return "Pad " + b.Name
+	}
+	return b.Name
+}
+
+func (b Binding) validate() error {
+	switch b.Device {
+	case Keyboard:
+		if sdl.GetKeyFromName(b.Name) == sdl.K_UNKNOWN {
+			return fmt.Errorf("unknown key %q", b.Name)
+		}
+	case Mouse:
+		if _, ok := mouseButtons[b.Name]; !ok {
+			return fmt.Errorf("unknown mouse button %q", b.Name)
+		}
+	case Controller:
+		if sdl.GameControllerGetButtonFromString(b.Name) == sdl.CONTROLLER_BUTTON_INVALID {
+			return fmt.Errorf("unknown controller button %q", b.Name)
+		}
+	default:
+		return fmt.Errorf("unknown device %q", b.Device)
+	}
+
+	return nil
+}
+
+// Bindings binds actions to keys and buttons. Each action can be bound to multiple of them.
+type Bindings map[Action][]Binding
+
+// DefaultBindings returns default controls
+func DefaultBindings() Bindings {
+	return Bindings{
+		Flap: {
+			{Keyboard, "Space"}, {Keyboard, "Up"}, {Keyboard, "W"},
+			{Mouse, "left"}, {Controller, "a"},
+		},
+		Pause: {
+			{Keyboard, "P"}, {Keyboard, "Escape"}, {Controller, "start"},
+		},
+		Quit: {
+			{Keyboard, "Q"}, {Controller, "guide"},
+		},
+		Confirm: {
+			{Keyboard, "Return"}, {Keyboard, "Space"}, {Mouse, "left"}, {Controller, "a"},
+		},
+		Back: {
+			{Keyboard, "Escape"}, {Keyboard, "Backspace"}, {Controller, "b"}, {Controller, "back"},
+		},
+		Mute: {
+			{Keyboard, "M"},
+		},
+	}
+}
+
+// LoadBindings reads bindings from the file. Actions missing in the file, e.g. added after the
+// file was saved, get default bindings.
+func LoadBindings(path string) (Bindings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Bindings
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("could not decode bindings: %v", err)
+	}
+
+	if b == nil {
+		b = Bindings{}
+	}
+	for action, bindings := range DefaultBindings() {
+		if _, ok := b[action]; !ok {
+			b[action] = bindings
+		}
+	}
+
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Save writes bindings to the file
+func (b Bindings) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode bindings: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory: %v", err)
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// Clone returns a copy of b
+func (b Bindings) Clone() Bindings {
+	c := make(Bindings, len(b))
+	for a, bindings := range b {
+		c[a] = append([]Binding(nil), bindings...)
+	}
+	return c
+}
+
+// Bind binds action to the binding. It fails if the binding is unknown or already used by an
+// action which conflicts with the given one.
+func (b Bindings) Bind(action Action, binding Binding) error {
+	if err := binding.validate(); err != nil {
+		return err
+	}
+
+	for other, bindings := range b {
+		if other == action || !conflicts(action, other) {
+			continue
+		}
+		for _, ob := range bindings {
+			if ob == binding {
+				return fmt.Errorf("%v is already bound to %v", binding, other)
+			}
+		}
+	}
+
+	for _, ob := range b[action] {
+		if ob == binding {
+			return nil
+		}
+	}
+	b[action] = append(b[action], binding)
+
+	return nil
+}
+
+// Validate checks that every action is bound and there are no conflicting bindings
+func (b Bindings) Validate() error {
+	for _, action := range Actions {
+		if len(b[action]) == 0 {
+			return fmt.Errorf("%v is not bound", action)
+		}
+	}
+
+	for action, bindings := range b {
+		if action.String() == "" {
+			return fmt.Errorf("unknown action %d", action)
+		}
+
+		for _, binding := range bindings {
+			if err := binding.validate(); err != nil {
+				return fmt.Errorf("%v: %v", action, err)
+			}
+		}
+	}
+
+	for _, a := range Actions {
+		for _, other := range Actions {
+			if a >= other || !conflicts(a, other) {
+				continue
+			}
+			for _, ab := range b[a] {
+				for _, ob := range b[other] {
+					if ab == ob {
+						return fmt.Errorf("%v is bound to both %v and %v", ab, a, other)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// conflicts reports whether actions can't share a binding because they are used at the same
+// time. Gameplay and menu actions are never used together, so they can share bindings.
+func conflicts(a, b Action) bool {
+	gameplay := Flap | Pause | Quit | Mute
+	menu := Confirm | Back | Quit | Mute
+	return (gameplay.Has(a) && gameplay.Has(b)) || (menu.Has(a) && menu.Has(b))
+}
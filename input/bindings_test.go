@@ -0,0 +1,142 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBindingsBind(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  Action
+		binding Binding
+		ok      bool
+	}{
+		{"new key", Flap, Binding{Keyboard, "F"}, true},
+		{"bound key", Flap, Binding{Keyboard, "Space"}, true},
+		{"mouse button", Pause, Binding{Mouse, "right"}, true},
+		{"controller button", Pause, Binding{Controller, "x"}, true},
+		{"menu key of gameplay", Flap, Binding{Keyboard, "Return"}, true},
+		{"conflicting key", Flap, Binding{Keyboard, "P"}, false},
+		{"conflicting button", Back, Binding{Controller, "a"}, false},
+		{"key of both", Confirm, Binding{Keyboard, "M"}, false},
+		{"unknown key", Flap, Binding{Keyboard, "NoSuchKey"}, false},
+		{"unknown mouse button", Flap, Binding{Mouse, "button9"}, false},
+		{"unknown controller button", Flap, Binding{Controller, "z"}, false},
+		{"unknown device", Flap, Binding{"joystick", "1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := DefaultBindings()
+			before := len(b[tt.action])
+
+			err := b.Bind(tt.action, tt.binding)
+			if tt.ok && err != nil {
+				t.Fatalf("Bind() error: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("Bind() has accepted %v", tt.binding)
+				}
+				if len(b[tt.action]) != before {
+					t.Errorf("rejected binding is added")
+				}
+				return
+			}
+
+			if err := b.Validate(); err != nil {
+				t.Errorf("bindings are invalid after Bind(): %v", err)
+			}
+			n := 0
+			for _, bb := range b[tt.action] {
+				if bb == tt.binding {
+					n++
+				}
+			}
+			if n != 1 {
+				t.Errorf("%v is bound %d times, want once", tt.binding, n)
+			}
+		})
+	}
+}
+
+func TestBindingsValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(b Bindings)
+		ok   bool
+	}{
+		{"default", func(b Bindings) {}, true},
+		{"unbound action", func(b Bindings) { b[Mute] = nil }, false},
+		{"conflict", func(b Bindings) { b[Pause] = append(b[Pause], Binding{Keyboard, "Space"}) }, false},
+		{"unknown key", func(b Bindings) { b[Mute] = []Binding{{Keyboard, "NoSuchKey"}} }, false},
+		{"unknown action", func(b Bindings) { b[Action(1<<10)] = []Binding{{Keyboard, "K"}} }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := DefaultBindings()
+			tt.edit(b)
+
+			err := b.Validate()
+			if tt.ok && err != nil {
+				t.Errorf("Validate() error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("Validate() has accepted invalid bindings")
+			}
+		})
+	}
+}
+
+func TestBindingsSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "controls", "controls.json")
+
+	b := DefaultBindings()
+	if err := b.Bind(Flap, Binding{Keyboard, "F"}); err != nil {
+		t.Fatalf("Bind() error: %v", err)
+	}
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := LoadBindings(path)
+	if err != nil {
+		t.Fatalf("LoadBindings() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, b) {
+		t.Errorf("loaded bindings %v, want %v", loaded, b)
+	}
+}
+
+func TestLoadBindingsDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "controls.json")
+	if err := os.WriteFile(path, []byte(`{"flap": [{"device": "key", "name": "F"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := LoadBindings(path)
+	if err != nil {
+		t.Fatalf("LoadBindings() error: %v", err)
+	}
+	if want := []Binding{{Keyboard, "F"}}; !reflect.DeepEqual(b[Flap], want) {
+		t.Errorf("flap is bound to %v, want %v", b[Flap], want)
+	}
+	if want := DefaultBindings()[Mute]; !reflect.DeepEqual(b[Mute], want) {
+		t.Errorf("missing mute is bound to %v, want defaults %v", b[Mute], want)
+	}
+}
+
+func TestLoadBindingsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "controls.json")
+	if err := os.WriteFile(path, []byte(`{"flap": [{"device": "key", "name": "P"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadBindings(path); err == nil {
+		t.Errorf("LoadBindings() has accepted flap bound to the pause key")
+	}
+}
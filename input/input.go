@@ -0,0 +1,232 @@
+// Package input maps keyboard, mouse, game controller and touch events to abstract
+// actions, so scenes don't depend on the device the player uses.
+package input
+
+import (
+	"fmt"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Action is a set of abstract actions triggered by the player
+type Action uint
+
+// Actions the player can trigger
+const (
+	Flap Action = 1 << iota
+	Pause
+	Quit
+	Confirm
+	Back
+	Mute
+)
+
+// Actions lists all actions in the order they are shown to the player
+var Actions = []Action{Flap, Pause, Quit, Confirm, Back, Mute}
+
+var actionNames = map[Action]string{
+	Flap:    "flap",
+	Pause:   "pause",
+	Quit:    "quit",
+	Confirm: "confirm",
+	Back:    "back",
+	Mute:    "mute",
+}
+
+// Has reports whether a contains any of actions
+func (a Action) Has(actions Action) bool {
+	return a&actions != 0
+}
+
+// String returns name of a single action
+func (a Action) String() string {
+	return actionNames[a]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (a Action) MarshalText() ([]byte, error) {
+	name, ok := actionNames[a]
+	if !ok {
+		return nil, fmt.Errorf("unknown action %d", a)
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (a *Action) UnmarshalText(text []byte) error {
+	for action, name := range actionNames {
+		if name == string(text) {
+			*a = action
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action %q", text)
+}
+
+// Event is an input event passed to scenes
+type Event struct {
+	// Actions is a set of actions triggered by the event. It's empty for events which
+	// aren't mapped to any action.
+	Actions Action
+
+	// X and Y are position of mouse or touch events on the logical screen
+	X int32
+	Y int32
+
+	// SDL is the original SDL event
+	SDL sdl.Event
+}
+
+// Pressed reports whether the event is a press of any key, mouse or controller button or a touch,
+// whether it's bound to an action or not
+func (e Event) Pressed() bool {
+	switch event := e.SDL.(type) {
+	case *sdl.KeyboardEvent:
+		return event.Type == sdl.KEYDOWN && event.Repeat == 0
+	case *sdl.MouseButtonEvent:
+		return event.Type == sdl.MOUSEBUTTONDOWN
+	case *sdl.ControllerButtonEvent:
+		return event.Type == sdl.CONTROLLERBUTTONDOWN
+	case *sdl.TouchFingerEvent:
+		return event.Type == sdl.FINGERDOWN
+	}
+	return false
+}
+
+// Mapper maps SDL events to actions. Bindings of the mapper can be changed while it's used. Like
+// the rest of SDL calls, methods are called from the main thread.
+type Mapper struct {
+	bindings Bindings
+	keys     map[sdl.Keycode]Action
+	mouse    map[uint8]Action
+	buttons  map[uint8]Action
+	touch    Action
+	viewport Viewport
+
+	controllers map[sdl.JoystickID]*sdl.GameController
+}
+
+// NewMapper creates new Mapper with given bindings
+func NewMapper(b Bindings) *Mapper {
+	m := &Mapper{
+		touch:       Flap | Confirm,
+		controllers: make(map[sdl.JoystickID]*sdl.GameController),
+	}
+	m.SetBindings(b)
+
+	return m
+}
+
+// SetBindings replaces bindings of the mapper
+func (m *Mapper) SetBindings(b Bindings) {
+	keys := make(map[sdl.Keycode]Action)
+	mouse := make(map[uint8]Action)
+	buttons := make(map[uint8]Action)
+	for action, bindings := range b {
+		for _, binding := range bindings {
+			switch binding.Device {
+			case Keyboard:
+				keys[sdl.GetKeyFromName(binding.Name)] |= action
+			case Mouse:
+				mouse[mouseButtons[binding.Name]] |= action
+			case Controller:
+				button := sdl.GameControllerGetButtonFromString(binding.Name)
+				buttons[uint8(button)] |= action
+			}
+		}
+	}
+
+	m.bindings = b.Clone()
+	m.keys = keys
+	m.mouse = mouse
+	m.buttons = buttons
+}
+
+// SetViewport sets placement of the logical screen used to map mouse and touch positions
+func (m *Mapper) SetViewport(v Viewport) {
+	m.viewport = v
+}
+
+// Bindings returns a copy of current bindings
+func (m *Mapper) Bindings() Bindings {
+	return m.bindings.Clone()
+}
+
+// Map maps SDL event to input Event. It returns false for events which scenes aren't
+// interested in.
+func (m *Mapper) Map(event sdl.Event) (Event, bool) {
+	e := Event{SDL: event}
+
+	switch event := event.(type) {
+	case *sdl.KeyboardEvent:
+		if event.Type == sdl.KEYDOWN && event.Repeat == 0 {
+			e.Actions = m.keys[event.Keysym.Sym]
+		}
+
+	case *sdl.MouseButtonEvent:
+		// touches are reported as touch events, skip mouse events SDL emulates for them
+		if event.Which == sdl.TOUCH_MOUSEID {
+			return e, false
+		}
+		if event.Type == sdl.MOUSEBUTTONDOWN {
+			e.Actions = m.mouse[event.Button]
+		}
+		e.X, e.Y = m.viewport.ToLogical(event.X, event.Y)
+
+	case *sdl.MouseMotionEvent:
+		if event.Which == sdl.TOUCH_MOUSEID {
+			return e, false
+		}
+		e.X, e.Y = m.viewport.ToLogical(event.X, event.Y)
+
+	case *sdl.ControllerButtonEvent:
+		if event.Type == sdl.CONTROLLERBUTTONDOWN {
+			e.Actions = m.buttons[event.Button]
+		}
+
+	case *sdl.TouchFingerEvent:
+		if event.Type == sdl.FINGERDOWN {
+			e.Actions = m.touch
+		}
+		x := int32(event.X * float32(m.viewport.WindowWidth))
+		y := int32(event.Y * float32(m.viewport.WindowHeight))
+		e.X, e.Y = m.viewport.ToLogical(x, y)
+
+	case *sdl.ControllerDeviceEvent:
+		m.handleDevice(event)
+		return e, false
+
+	case *sdl.WindowEvent, *sdl.TextInputEvent:
+		// passed as is, so scenes can react on focus changes and read typed text
+
+	default:
+		return e, false
+	}
+
+	return e, true
+}
+
+// Close closes all opened game controllers
+func (m *Mapper) Close() {
+	for id, c := range m.controllers {
+		c.Close()
+		delete(m.controllers, id)
+	}
+}
+
+func (m *Mapper) handleDevice(event *sdl.ControllerDeviceEvent) {
+	switch event.Type {
+	case sdl.CONTROLLERDEVICEADDED:
+		c := sdl.GameControllerOpen(int(event.Which))
+		if c == nil {
+			return
+		}
+		m.controllers[c.Joystick().InstanceID()] = c
+
+	case sdl.CONTROLLERDEVICEREMOVED:
+		if c, ok := m.controllers[event.Which]; ok {
+			c.Close()
+			delete(m.controllers, event.Which)
+		}
+	}
+}
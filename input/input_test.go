@@ -0,0 +1,77 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestMapperMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   sdl.Event
+		actions Action
+		mapped  bool
+	}{
+		{
+			name:    "key",
+			event:   &sdl.KeyboardEvent{Type: sdl.KEYDOWN, Keysym: sdl.Keysym{Sym: sdl.K_SPACE}},
+			actions: Flap | Confirm,
+			mapped:  true,
+		},
+		{
+			name:   "repeated key",
+			event:  &sdl.KeyboardEvent{Type: sdl.KEYDOWN, Repeat: 1, Keysym: sdl.Keysym{Sym: sdl.K_SPACE}},
+			mapped: true,
+		},
+		{
+			name:   "released key",
+			event:  &sdl.KeyboardEvent{Type: sdl.KEYUP, Keysym: sdl.Keysym{Sym: sdl.K_SPACE}},
+			mapped: true,
+		},
+		{
+			name:   "unbound key",
+			event:  &sdl.KeyboardEvent{Type: sdl.KEYDOWN, Keysym: sdl.Keysym{Sym: sdl.K_F12}},
+			mapped: true,
+		},
+		{
+			name:    "mouse",
+			event:   &sdl.MouseButtonEvent{Type: sdl.MOUSEBUTTONDOWN, Button: sdl.BUTTON_LEFT},
+			actions: Flap | Confirm,
+			mapped:  true,
+		},
+		{
+			name:  "emulated mouse",
+			event: &sdl.MouseButtonEvent{Type: sdl.MOUSEBUTTONDOWN, Which: sdl.TOUCH_MOUSEID, Button: sdl.BUTTON_LEFT},
+		},
+		{
+			name:    "controller",
+			event:   &sdl.ControllerButtonEvent{Type: sdl.CONTROLLERBUTTONDOWN, Button: sdl.CONTROLLER_BUTTON_B},
+			actions: Back,
+			mapped:  true,
+		},
+		{
+			name:    "touch",
+			event:   &sdl.TouchFingerEvent{Type: sdl.FINGERDOWN},
+			actions: Flap | Confirm,
+			mapped:  true,
+		},
+		{
+			name:  "quit",
+			event: &sdl.QuitEvent{Type: sdl.QUIT},
+		},
+	}
+
+	m := NewMapper(DefaultBindings())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok := m.Map(tt.event)
+			if ok != tt.mapped {
+				t.Fatalf("Map() reports %v, want %v", ok, tt.mapped)
+			}
+			if e.Actions != tt.actions {
+				t.Errorf("Map() = %b, want %b", e.Actions, tt.actions)
+			}
+		})
+	}
+}
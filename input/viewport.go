@@ -0,0 +1,60 @@
+package input
+
+// Viewport describes where the logical screen of the game is placed in the window. The
+// logical screen keeps its aspect ratio, the rest of the window is left empty.
+type Viewport struct {
+	WindowWidth  int32
+	WindowHeight int32
+
+	LogicalWidth  int32
+	LogicalHeight int32
+
+	// X and Y are offsets of the logical screen in the window
+	X     int32
+	Y     int32
+	Scale float32
+}
+
+// NewViewport fits the logical screen into the window. If integer is set, the screen is
+// scaled by a whole factor unless the window is smaller than the screen.
+func NewViewport(windowWidth, windowHeight, logicalWidth, logicalHeight int32, integer bool) Viewport {
+	scale := float32(windowWidth) / float32(logicalWidth)
+	if s := float32(windowHeight) / float32(logicalHeight); s < scale {
+		scale = s
+	}
+
+	if integer && scale >= 1 {
+		scale = float32(int(scale))
+	}
+
+	return Viewport{
+		WindowWidth:   windowWidth,
+		WindowHeight:  windowHeight,
+		LogicalWidth:  logicalWidth,
+		LogicalHeight: logicalHeight,
+		X:             (windowWidth - int32(float32(logicalWidth)*scale)) / 2,
+		Y:             (windowHeight - int32(float32(logicalHeight)*scale)) / 2,
+		Scale:         scale,
+	}
+}
+
+// ToLogical converts window coordinates to coordinates of the logical screen. Points in the
+// empty bars around the screen are moved to its nearest edge.
+func (v Viewport) ToLogical(x, y int32) (int32, int32) {
+	if v.Scale == 0 {
+		return x, y
+	}
+	lx := int32(float32(x-v.X) / v.Scale)
+	ly := int32(float32(y-v.Y) / v.Scale)
+	return clamp(lx, v.LogicalWidth-1), clamp(ly, v.LogicalHeight-1)
+}
+
+func clamp(v, max int32) int32 {
+	if v < 0 {
+		return 0
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
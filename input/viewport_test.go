@@ -0,0 +1,53 @@
+package input
+
+import "testing"
+
+func TestViewportToLogical(t *testing.T) {
+	// 800x600 screen in 1000x600 window is centered with bars of 100 pixels on both sides
+	v := NewViewport(1000, 600, 800, 600, false)
+
+	tests := []struct {
+		name   string
+		x, y   int32
+		lx, ly int32
+	}{
+		{"top left", 100, 0, 0, 0},
+		{"center", 500, 300, 400, 300},
+		{"bottom right", 899, 599, 799, 599},
+		{"left bar", 50, 300, 0, 300},
+		{"right bar", 950, 300, 799, 300},
+		{"outside", -10, 700, 0, 599},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lx, ly := v.ToLogical(tt.x, tt.y)
+			if lx != tt.lx || ly != tt.ly {
+				t.Errorf("ToLogical(%d, %d) = %d, %d, want %d, %d", tt.x, tt.y, lx, ly, tt.lx, tt.ly)
+			}
+		})
+	}
+}
+
+func TestNewViewportInteger(t *testing.T) {
+	tests := []struct {
+		name    string
+		w, h    int32
+		integer bool
+		scale   float32
+		x, y    int32
+	}{
+		{"fractional", 1200, 900, false, 1.5, 0, 0},
+		{"integer", 1200, 900, true, 1, 200, 150},
+		{"smaller window", 400, 300, true, 0.5, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewViewport(tt.w, tt.h, 800, 600, tt.integer)
+			if v.Scale != tt.scale || v.X != tt.x || v.Y != tt.y {
+				t.Errorf("viewport is scaled by %v at %d, %d, want %v at %d, %d", v.Scale, v.X, v.Y, tt.scale, tt.x, tt.y)
+			}
+		})
+	}
+}
@@ -1,21 +1,46 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
-	"time"
 
+	"github.com/spoof/go-flappybird/audio"
+	"github.com/spoof/go-flappybird/config"
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render/sdlrender"
+	"github.com/spoof/go-flappybird/replay"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/save"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 )
 
-const (
-	windowWidth  = 800
-	windowHeight = 600
+var (
+	cfg = config.Default()
+
+	configFile = flag.String("config", "", "config file; config.json in the user config directory if not set")
+	seed       = flag.Int64("seed", 0, "seed for pipe generation; random for every game if not set")
+	replayFile = flag.String("replay", "", "play back the replay file instead of reading input")
+	assetsDir  = flag.String("assets", "", "directory with images, fonts and sounds replacing built-in ones")
 )
 
+func init() {
+	// SDL expects all its calls from the main thread, which runs main only if it's locked
+	// before main starts
+	runtime.LockOSThread()
+
+	cfg.RegisterFlags(flag.CommandLine)
+}
+
 func main() {
+	flag.Parse()
+
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v", err)
 		os.Exit(2)
@@ -23,55 +48,197 @@ func main() {
 }
 
 func run() error {
-	err := sdl.Init(sdl.INIT_EVERYTHING)
+	dir, err := configDir()
+	if err != nil {
+		return fmt.Errorf("could not find config directory: %v", err)
+	}
+
+	configPath := *configFile
+	if configPath == "" {
+		configPath = filepath.Join(dir, "config.json")
+	}
+
+	if err := loadConfig(configPath); err != nil {
+		return err
+	}
+
+	res.SetOverrideDir(*assetsDir)
+	theme, err := res.SetTheme(cfg.Theme)
+	if err != nil {
+		return fmt.Errorf("could not load theme %s: %v", cfg.Theme, err)
+	}
+
+	// audio is initialized separately, so the game runs without audio device
+	err = sdl.Init(sdl.INIT_EVERYTHING &^ sdl.INIT_AUDIO)
 	if err != nil {
 		return fmt.Errorf("could not initialize SDL: %v", err)
 	}
 	defer sdl.Quit()
 
+	a, err := audio.Open()
+	if err != nil {
+		return fmt.Errorf("could not initialize audio: %v", err)
+	}
+	defer a.Close()
+	a.SetVolume(cfg.Audio.Master, cfg.Audio.Music, cfg.Audio.SFX)
+	a.SetMuted(cfg.Audio.Muted)
+
 	if err := ttf.Init(); err != nil {
 		return fmt.Errorf("could not initialize TTF: %v", err)
 	}
 
-	w, renderer, err := sdl.CreateWindowAndRenderer(windowWidth, windowHeight, sdl.WINDOW_SHOWN)
+	w, renderer, err := createWindow(cfg)
 	if err != nil {
-		return fmt.Errorf("could not create window: %v", err)
+		return err
 	}
 	defer w.Destroy()
+	defer renderer.Destroy()
+
+	screen := sdlrender.New(renderer)
+	resources := res.NewManager(screen, theme)
+	defer func() {
+		if err := resources.Close(); err != nil {
+			log.Print(err)
+		}
+	}()
+
+	controlsPath := filepath.Join(dir, "controls.json")
+	bindings, err := input.LoadBindings(controlsPath)
+	if os.IsNotExist(err) {
+		bindings, err = input.DefaultBindings(), nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not load controls from %s: %v", controlsPath, err)
+	}
 
-	w.SetTitle("Flappy Bird")
+	mapper := input.NewMapper(bindings)
+	defer mapper.Close()
 
-	sceneManager, err := NewSceneManager(renderer, windowWidth, windowHeight)
+	if err := fitViewport(w, renderer, cfg, mapper); err != nil {
+		return err
+	}
+
+	store := save.NewStore(filepath.Join(dir, "save.json"))
+
+	paths := Paths{
+		Config:   configPath,
+		Controls: controlsPath,
+		Themes:   filepath.Join(dir, "themes"),
+		Replays:  dir,
+	}
+	sceneManager, err := NewSceneManager(resources, cfg, mapper, paths, store, a)
 	if err != nil {
 		return fmt.Errorf("could not create scene manager: %v", err)
 	}
 	defer sceneManager.Destroy()
 
-	events := make(chan sdl.Event)
-	errc := sceneManager.Run(events, renderer)
+	if isFlagSet("seed") {
+		sceneManager.SetSeed(*seed)
+	}
 
-	runtime.LockOSThread()
-	for {
-		event := sdl.PollEvent()
-		if event != nil {
-			switch event.(type) {
-			case *sdl.QuitEvent:
-				close(events)
-				<-errc
-				return nil
-			case *sdl.MouseButtonEvent:
-				events <- event
+	if *replayFile != "" {
+		rep, err := replay.Load(*replayFile)
+		if err != nil {
+			return fmt.Errorf("could not load replay: %v", err)
+		}
+		sceneManager.SetReplay(rep)
+	}
+
+	// closing the window or an interrupt stops the loop
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	poll := func() ([]input.Event, error) {
+		var events []input.Event
+		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+			if _, ok := event.(*sdl.QuitEvent); ok {
+				cancel()
+				return nil, nil
+			}
+
+			if isFullscreenToggle(event) {
+				if err := toggleFullscreen(w); err != nil {
+					return nil, fmt.Errorf("could not toggle fullscreen: %v", err)
+				}
+				continue
+			}
+
+			if isResize(event) {
+				if err := fitViewport(w, renderer, cfg, mapper); err != nil {
+					return nil, err
+				}
+			}
+
+			if e, ok := mapper.Map(event); ok {
+				events = append(events, e)
 			}
 		}
 
 		select {
-		case e, ok := <-errc:
-			if !ok {
-				return nil
+		case fullscreen := <-sceneManager.WindowModes():
+			if err := setFullscreen(w, fullscreen); err != nil {
+				return nil, fmt.Errorf("could not switch window mode: %v", err)
 			}
-			return fmt.Errorf("SceneManager got error %v", e)
 		default:
-			time.Sleep(10 * time.Millisecond)
 		}
+
+		return events, nil
+	}
+
+	if err := sceneManager.Run(ctx, screen, screen, poll); err != nil {
+		return fmt.Errorf("SceneManager got error %v", err)
+	}
+	return nil
+}
+
+// loadConfig reads config file at path and applies command line flags on top of it
+func loadConfig(path string) error {
+	err := cfg.Load(path)
+	if os.IsNotExist(err) && *configFile == "" {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not load config from %s: %v", path, err)
+	}
+
+	// the file has overwritten values set by flags, so parse them once again
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return err
+	}
+
+	// difficulty of the file has been applied by Load, so the preset is applied again only if
+	// it's given by flag. Physics given by flags is kept.
+	if isFlagSet("difficulty") {
+		if err := cfg.SetDifficulty(cfg.Difficulty); err != nil {
+			return fmt.Errorf("invalid config: %v", err)
+		}
+		if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %v", err)
 	}
+
+	return nil
+}
+
+// configDir returns directory where the game keeps its settings
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "flappybird"), nil
+}
+
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
@@ -0,0 +1,304 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	// images of the game are PNG files
+	_ "image/png"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Image draws to image.RGBA in memory without a window or video driver. Textures are drawn like
+// SDL draws them, with nearest pixel sampling and alpha blending, so frames match the window.
+// Text is rendered by a pure Go rasterizer, its glyphs differ from SDL_ttf ones slightly.
+type Image struct {
+	frame  *image.RGBA
+	target *image.RGBA
+}
+
+type imageTexture struct {
+	img  *image.RGBA
+	tint color.NRGBA
+}
+
+type imageFont struct {
+	face    font.Face
+	ascent  int
+	descent int
+}
+
+// NewImage creates new Image renderer with frame of size width x height
+func NewImage(width, height int) *Image {
+	frame := image.NewRGBA(image.Rect(0, 0, width, height))
+	return &Image{frame: frame, target: frame}
+}
+
+// Frame returns the image frames are drawn to. It's changed by the following draws, so it must
+// be copied to be kept.
+func (r *Image) Frame() *image.RGBA {
+	return r.frame
+}
+
+// LoadTexture implements Renderer
+func (r *Image) LoadTexture(data []byte) (Texture, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode image: %v", err)
+	}
+
+	b := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(img, img.Bounds(), src, b.Min, draw.Src)
+
+	return newImageTexture(img), nil
+}
+
+// LoadFont implements Renderer. Size is in points at 72 DPI like in SDL_ttf, so it's the same
+// in pixels.
+func (r *Image) LoadFont(data []byte, size int) (Font, error) {
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse font: %v", err)
+	}
+
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: float64(size), DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("could not create font face: %v", err)
+	}
+
+	m := face.Metrics()
+	return &imageFont{face: face, ascent: m.Ascent.Ceil(), descent: m.Descent.Ceil()}, nil
+}
+
+// TextTexture implements Renderer. Outline is made by spreading glyphs in a circle of the
+// outline radius, so the texture is larger by the outline on every side like in SDL_ttf.
+func (r *Image) TextTexture(f Font, text string, outline int, solid bool) (Texture, error) {
+	ft, ok := f.(*imageFont)
+	if !ok {
+		return nil, fmt.Errorf("font %T is not loaded by image renderer", f)
+	}
+	if outline < 0 {
+		outline = 0
+	}
+
+	w, h, err := ft.Size(text)
+	if err != nil {
+		return nil, err
+	}
+	mask := image.NewAlpha(image.Rect(0, 0, w+2*outline, h+2*outline))
+	d := &font.Drawer{Dst: mask, Src: image.Opaque, Face: ft.face, Dot: fixed.P(outline, outline+ft.ascent)}
+	d.DrawString(text)
+
+	if outline > 0 {
+		mask = spread(mask, outline)
+	}
+	if solid {
+		for i, a := range mask.Pix {
+			if a >= 128 {
+				mask.Pix[i] = 255
+			} else {
+				mask.Pix[i] = 0
+			}
+		}
+	}
+
+	// white glyphs premultiplied by alpha of the mask
+	img := image.NewRGBA(mask.Rect)
+	for i, a := range mask.Pix {
+		p := img.Pix[i*4 : i*4+4]
+		p[0], p[1], p[2], p[3] = a, a, a, a
+	}
+
+	return newImageTexture(img), nil
+}
+
+// NewTarget implements Renderer
+func (r *Image) NewTarget(w, h int) (Texture, error) {
+	return newImageTexture(image.NewRGBA(image.Rect(0, 0, w, h))), nil
+}
+
+// SetTarget implements Renderer
+func (r *Image) SetTarget(t Texture) error {
+	if t == nil {
+		r.target = r.frame
+		return nil
+	}
+
+	texture, err := r.texture(t)
+	if err != nil {
+		return err
+	}
+	r.target = texture.img
+	return nil
+}
+
+// Clear implements Renderer
+func (r *Image) Clear(c color.NRGBA) error {
+	draw.Draw(r.target, r.target.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return nil
+}
+
+// DrawTexture implements Renderer
+func (r *Image) DrawTexture(t Texture, src, dst *image.Rectangle) error {
+	return r.draw(t, src, dst, 0, FlipNone)
+}
+
+// DrawSprite implements Renderer
+func (r *Image) DrawSprite(t Texture, src, dst *image.Rectangle, angle float64, flip Flip) error {
+	return r.draw(t, src, dst, angle, flip)
+}
+
+// FillRect implements Renderer
+func (r *Image) FillRect(rect *image.Rectangle, c color.NRGBA) error {
+	bounds := r.target.Bounds()
+	if rect != nil {
+		bounds = bounds.Intersect(*rect)
+	}
+	draw.Draw(r.target, bounds, image.NewUniform(c), image.Point{}, draw.Over)
+	return nil
+}
+
+// draw maps every pixel of the target covered by dst rotated around its center back to src
+// and blends the nearest pixel of the texture over it
+func (r *Image) draw(t Texture, src, dst *image.Rectangle, angle float64, flip Flip) error {
+	texture, err := r.texture(t)
+	if err != nil {
+		return err
+	}
+
+	s := texture.img.Rect
+	if src != nil {
+		s = *src
+	}
+	d := r.target.Rect
+	if dst != nil {
+		d = *dst
+	}
+	if s.Empty() || d.Empty() {
+		return nil
+	}
+
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	w, h := float64(d.Dx()), float64(d.Dy())
+	cx, cy := float64(d.Min.X)+w/2, float64(d.Min.Y)+h/2
+
+	// bounding box of the rotated dst
+	hw := (math.Abs(w*cos) + math.Abs(h*sin)) / 2
+	hh := (math.Abs(w*sin) + math.Abs(h*cos)) / 2
+	box := image.Rect(int(math.Floor(cx-hw)), int(math.Floor(cy-hh)), int(math.Ceil(cx+hw)), int(math.Ceil(cy+hh)))
+	box = box.Intersect(r.target.Bounds())
+
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			// center of the pixel in dst before rotation
+			dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+			u := dx*cos + dy*sin + w/2
+			v := -dx*sin + dy*cos + h/2
+			if u < 0 || v < 0 || u >= w || v >= h {
+				continue
+			}
+			if flip&FlipHorizontal != 0 {
+				u = w - u
+			}
+			if flip&FlipVertical != 0 {
+				v = h - v
+			}
+
+			sx := s.Min.X + int(math.Min(u*float64(s.Dx())/w, float64(s.Dx()-1)))
+			sy := s.Min.Y + int(math.Min(v*float64(s.Dy())/h, float64(s.Dy()-1)))
+			if !image.Pt(sx, sy).In(texture.img.Rect) {
+				continue
+			}
+			blend(r.target, x, y, texture.img, sx, sy, texture.tint)
+		}
+	}
+
+	return nil
+}
+
+func (r *Image) texture(t Texture) (*imageTexture, error) {
+	texture, ok := t.(*imageTexture)
+	if !ok {
+		return nil, fmt.Errorf("texture %T is not created by image renderer", t)
+	}
+	return texture, nil
+}
+
+// blend blends pixel (sx, sy) of src tinted by tint over pixel (x, y) of dst. Pixels of
+// image.RGBA are premultiplied by alpha, so the tint alpha scales color and alpha alike.
+func blend(dst *image.RGBA, x, y int, src *image.RGBA, sx, sy int, tint color.NRGBA) {
+	s, d := src.PixOffset(sx, sy), dst.PixOffset(x, y)
+
+	a := uint32(src.Pix[s+3]) * uint32(tint.A) / 255
+	if a == 0 {
+		return
+	}
+
+	mod := [3]uint32{uint32(tint.R), uint32(tint.G), uint32(tint.B)}
+	for i, m := range mod {
+		c := uint32(src.Pix[s+i]) * m / 255 * uint32(tint.A) / 255
+		dst.Pix[d+i] = uint8(c + uint32(dst.Pix[d+i])*(255-a)/255)
+	}
+	dst.Pix[d+3] = uint8(a + uint32(dst.Pix[d+3])*(255-a)/255)
+}
+
+// spread returns mask where every pixel is the most opaque pixel of mask within radius around it
+func spread(mask *image.Alpha, radius int) *image.Alpha {
+	b := mask.Rect
+	out := image.NewAlpha(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			var max uint8
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					if dx*dx+dy*dy > radius*radius || !image.Pt(x+dx, y+dy).In(b) {
+						continue
+					}
+					if a := mask.AlphaAt(x+dx, y+dy).A; a > max {
+						max = a
+					}
+				}
+			}
+			out.SetAlpha(x, y, color.Alpha{A: max})
+		}
+	}
+	return out
+}
+
+func newImageTexture(img *image.RGBA) *imageTexture {
+	return &imageTexture{img: img, tint: White}
+}
+
+func (t *imageTexture) Size() (w, h int) {
+	return t.img.Rect.Dx(), t.img.Rect.Dy()
+}
+
+func (t *imageTexture) SetTint(c color.NRGBA) {
+	t.tint = c
+}
+
+func (t *imageTexture) Destroy() {}
+
+// Size implements Font. Height of any text is height of the font, so lines of text rendered
+// separately are aligned.
+func (f *imageFont) Size(text string) (w, h int, err error) {
+	return font.MeasureString(f.face, text).Ceil(), f.ascent + f.descent, nil
+}
+
+// LineSkip implements Font
+func (f *imageFont) LineSkip() int {
+	return f.face.Metrics().Height.Ceil()
+}
+
+// Close implements Font
+func (f *imageFont) Close() {
+	f.face.Close()
+}
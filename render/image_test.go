@@ -0,0 +1,275 @@
+package render_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spoof/go-flappybird/render"
+)
+
+var (
+	black = color.RGBA{A: 255}
+	red   = color.RGBA{R: 255, A: 255}
+	green = color.RGBA{G: 255, A: 255}
+	blue  = color.RGBA{B: 255, A: 255}
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// quad returns texture of 2x2 pixels: red and green on top, blue and white at the bottom
+func quad(t *testing.T, r *render.Image) render.Texture {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.SetRGBA(0, 0, red)
+	img.SetRGBA(1, 0, green)
+	img.SetRGBA(0, 1, blue)
+	img.SetRGBA(1, 1, white)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	texture, err := r.LoadTexture(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadTexture() error: %v", err)
+	}
+	return texture
+}
+
+// pixels returns colors of the frame at points
+func pixels(r *render.Image, points ...image.Point) []color.RGBA {
+	var colors []color.RGBA
+	for _, p := range points {
+		colors = append(colors, r.Frame().RGBAAt(p.X, p.Y))
+	}
+	return colors
+}
+
+func rect(x, y, w, h int) *image.Rectangle {
+	r := image.Rect(x, y, x+w, y+h)
+	return &r
+}
+
+func TestImageDraw(t *testing.T) {
+	corners := []image.Point{{2, 2}, {5, 2}, {2, 5}, {5, 5}, {1, 1}, {6, 6}}
+
+	tests := []struct {
+		name string
+		draw func(r *render.Image, q render.Texture) error
+		want []color.RGBA
+	}{
+		{
+			name: "stretched",
+			draw: func(r *render.Image, q render.Texture) error {
+				return r.DrawTexture(q, nil, rect(2, 2, 4, 4))
+			},
+			want: []color.RGBA{red, green, blue, white, black, black},
+		},
+		{
+			name: "part",
+			draw: func(r *render.Image, q render.Texture) error {
+				return r.DrawTexture(q, rect(1, 0, 1, 2), rect(2, 2, 4, 4))
+			},
+			want: []color.RGBA{green, green, white, white, black, black},
+		},
+		{
+			name: "flipped horizontally",
+			draw: func(r *render.Image, q render.Texture) error {
+				return r.DrawSprite(q, nil, rect(2, 2, 4, 4), 0, render.FlipHorizontal)
+			},
+			want: []color.RGBA{green, red, white, blue, black, black},
+		},
+		{
+			name: "flipped both ways",
+			draw: func(r *render.Image, q render.Texture) error {
+				return r.DrawSprite(q, nil, rect(2, 2, 4, 4), 0, render.FlipHorizontal|render.FlipVertical)
+			},
+			want: []color.RGBA{white, blue, green, red, black, black},
+		},
+		{
+			name: "rotated clockwise",
+			draw: func(r *render.Image, q render.Texture) error {
+				return r.DrawSprite(q, nil, rect(2, 2, 4, 4), 90, render.FlipNone)
+			},
+			want: []color.RGBA{blue, red, white, green, black, black},
+		},
+		{
+			name: "whole target",
+			draw: func(r *render.Image, q render.Texture) error {
+				return r.DrawTexture(q, nil, nil)
+			},
+			want: []color.RGBA{red, green, blue, white, red, white},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := render.NewImage(8, 8)
+			if err := r.Clear(color.NRGBA{A: 255}); err != nil {
+				t.Fatalf("Clear() error: %v", err)
+			}
+
+			if err := tt.draw(r, quad(t, r)); err != nil {
+				t.Fatalf("draw error: %v", err)
+			}
+			got := pixels(r, corners...)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("pixel %v is %v, want %v", corners[i], got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestImageTint(t *testing.T) {
+	r := render.NewImage(2, 2)
+	r.Clear(color.NRGBA{A: 255})
+
+	q := quad(t, r)
+	q.SetTint(color.NRGBA{R: 255, G: 0, B: 255, A: 128})
+	if err := r.DrawTexture(q, nil, nil); err != nil {
+		t.Fatalf("DrawTexture() error: %v", err)
+	}
+
+	want := []color.RGBA{{R: 128, A: 255}, {A: 255}, {B: 128, A: 255}, {R: 128, B: 128, A: 255}}
+	got := pixels(r, image.Pt(0, 0), image.Pt(1, 0), image.Pt(0, 1), image.Pt(1, 1))
+	for i := range want {
+		if !near(got[i], want[i]) {
+			t.Errorf("pixel %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestImageFillRect(t *testing.T) {
+	r := render.NewImage(4, 4)
+	r.Clear(color.NRGBA{A: 255})
+
+	if err := r.FillRect(rect(2, 2, 10, 10), color.NRGBA{R: 255, A: 255}); err != nil {
+		t.Fatalf("FillRect() error: %v", err)
+	}
+	if err := r.FillRect(nil, color.NRGBA{G: 255, A: 128}); err != nil {
+		t.Fatalf("FillRect() error: %v", err)
+	}
+
+	got := pixels(r, image.Pt(0, 0), image.Pt(3, 3))
+	want := []color.RGBA{{G: 128, A: 255}, {R: 127, G: 128, A: 255}}
+	for i := range want {
+		if !near(got[i], want[i]) {
+			t.Errorf("pixel %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestImageTarget(t *testing.T) {
+	r := render.NewImage(4, 4)
+	r.Clear(color.NRGBA{A: 255})
+
+	target, err := r.NewTarget(2, 2)
+	if err != nil {
+		t.Fatalf("NewTarget() error: %v", err)
+	}
+	if err := r.SetTarget(target); err != nil {
+		t.Fatalf("SetTarget() error: %v", err)
+	}
+	r.FillRect(rect(0, 0, 1, 2), color.NRGBA{B: 255, A: 255})
+	if err := r.SetTarget(nil); err != nil {
+		t.Fatalf("SetTarget() error: %v", err)
+	}
+
+	if got := r.Frame().RGBAAt(0, 0); got != black {
+		t.Errorf("drawing to target has changed the frame: %v", got)
+	}
+
+	// transparent half of the target keeps the frame
+	r.DrawTexture(target, nil, nil)
+	if got := pixels(r, image.Pt(0, 0), image.Pt(3, 3)); got[0] != blue || got[1] != black {
+		t.Errorf("frame is %v, want blue and black", got)
+	}
+}
+
+func TestImageText(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "res", "fonts", "VanillaExtractRegular.ttf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := render.NewImage(1, 1)
+	font, err := r.LoadFont(data, 20)
+	if err != nil {
+		t.Fatalf("LoadFont() error: %v", err)
+	}
+	defer font.Close()
+
+	w, h, err := font.Size("Flappy")
+	if err != nil {
+		t.Fatalf("Size() error: %v", err)
+	}
+	if w <= 0 || h <= 0 || font.LineSkip() < h/2 {
+		t.Fatalf("text is %dx%d with line skip %d", w, h, font.LineSkip())
+	}
+
+	tests := []struct {
+		name    string
+		outline int
+		solid   bool
+	}{
+		{"blended", 0, false},
+		{"solid", 0, true},
+		{"outline", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			texture, err := r.TextTexture(font, "Flappy", tt.outline, tt.solid)
+			if err != nil {
+				t.Fatalf("TextTexture() error: %v", err)
+			}
+
+			tw, th := texture.Size()
+			if tw != w+2*tt.outline || th != h+2*tt.outline {
+				t.Errorf("texture is %dx%d, want %dx%d", tw, th, w+2*tt.outline, h+2*tt.outline)
+			}
+
+			frame := render.NewImage(tw, th)
+			frame.DrawTexture(texture, nil, nil)
+			opaque, partial := 0, 0
+			for _, p := range pixels(frame, points(tw, th)...) {
+				switch {
+				case p.A == 255:
+					opaque++
+				case p.A > 0:
+					partial++
+				}
+				if p.R != p.A || p.G != p.A || p.B != p.A {
+					t.Fatalf("text pixel %v isn't white", p)
+				}
+			}
+			if opaque == 0 {
+				t.Errorf("text has no opaque pixels")
+			}
+			if tt.solid && partial > 0 {
+				t.Errorf("solid text has %d translucent pixels", partial)
+			}
+		})
+	}
+}
+
+func points(w, h int) []image.Point {
+	var ps []image.Point
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			ps = append(ps, image.Pt(x, y))
+		}
+	}
+	return ps
+}
+
+// near reports whether colors differ by rounding only
+func near(a, b color.RGBA) bool {
+	d := func(x, y uint8) bool { return int(x)-int(y) <= 1 && int(y)-int(x) <= 1 }
+	return d(a.R, b.R) && d(a.G, b.G) && d(a.B, b.B) && d(a.A, b.A)
+}
@@ -0,0 +1,78 @@
+// Package render draws frames of the game. Renderer is implemented with SDL for the window in
+// package sdlrender and with image.RGBA in memory here, so frames are rendered headlessly for
+// tests, screenshots and thumbnails without SDL.
+package render
+
+import (
+	"image"
+	"image/color"
+)
+
+// White tint draws textures as they are
+var White = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+
+// Flip mirrors a sprite, flips are combined with |
+type Flip int
+
+// Flips
+const (
+	FlipNone       Flip = 0
+	FlipHorizontal Flip = 1
+	FlipVertical   Flip = 2
+)
+
+// Texture is an image drawn by the renderer which has created it
+type Texture interface {
+	// Size returns size of the texture in pixels
+	Size() (w, h int)
+	// SetTint sets color pixels of the texture are multiplied by when it's drawn. Alpha of the
+	// color is opacity of the texture.
+	SetTint(c color.NRGBA)
+	// Destroy frees the texture
+	Destroy()
+}
+
+// Font is a TrueType font at a point size loaded by the renderer which renders text with it
+type Font interface {
+	// Size returns size of text rendered with the font in pixels
+	Size(text string) (w, h int, err error)
+	// LineSkip returns recommended distance between lines of text
+	LineSkip() int
+	// Close frees the font
+	Close()
+}
+
+// Renderer draws textures and shapes to the frame or to a target texture
+type Renderer interface {
+	// LoadTexture creates texture from encoded image, e.g. contents of a PNG file
+	LoadTexture(data []byte) (Texture, error)
+	// LoadFont loads font from contents of a TTF file at point size
+	LoadFont(data []byte, size int) (Font, error)
+	// TextTexture renders text with font loaded by the renderer in white, so it's drawn in any
+	// color by tint. Outline greater than zero renders outline of that width around glyphs.
+	// Solid renders glyphs without anti-aliasing.
+	TextTexture(font Font, text string, outline int, solid bool) (Texture, error)
+	// NewTarget creates transparent texture of size w x h which can be drawn to
+	NewTarget(w, h int) (Texture, error)
+	// SetTarget makes following calls draw to t created by NewTarget, nil means the frame
+	SetTarget(t Texture) error
+
+	// Clear fills the target with c
+	Clear(c color.NRGBA) error
+	// DrawTexture draws src part of t stretched to dst. Nil src means the whole texture, nil dst
+	// means the whole target.
+	DrawTexture(t Texture, src, dst *image.Rectangle) error
+	// DrawSprite draws like DrawTexture, rotated by angle degrees clockwise around center of
+	// dst and flipped
+	DrawSprite(t Texture, src, dst *image.Rectangle, angle float64, flip Flip) error
+	// FillRect blends c over rect, nil rect means the whole target
+	FillRect(rect *image.Rectangle, c color.NRGBA) error
+}
+
+// Display shows frames drawn by a Renderer, e.g. in a window
+type Display interface {
+	// Present shows the frame drawn since the last Present
+	Present()
+	// VSync reports whether Present waits for vertical sync
+	VSync() bool
+}
@@ -0,0 +1,238 @@
+// Package sdlrender implements render.Renderer with the SDL renderer of the window
+package sdlrender
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/veandco/go-sdl2/img"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// Renderer draws with the SDL renderer of the window. It's the render.Display of the window as
+// well.
+type Renderer struct {
+	r *sdl.Renderer
+}
+
+type texture struct {
+	t    *sdl.Texture
+	w, h int
+}
+
+type font struct {
+	f *ttf.Font
+}
+
+// New creates new Renderer drawing with r
+func New(r *sdl.Renderer) *Renderer {
+	return &Renderer{r: r}
+}
+
+// VSync implements render.Display
+func (s *Renderer) VSync() bool {
+	info, err := s.r.GetInfo()
+	return err == nil && info.Flags&sdl.RENDERER_PRESENTVSYNC != 0
+}
+
+// Present implements render.Display
+func (s *Renderer) Present() {
+	s.r.Present()
+}
+
+// LoadTexture implements render.Renderer
+func (s *Renderer) LoadTexture(data []byte) (render.Texture, error) {
+	rw, err := res.NewRWops(data)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := img.LoadTextureRW(s.r, rw, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not load texture: %v", err)
+	}
+
+	_, _, w, h, err := t.Query()
+	if err != nil {
+		t.Destroy()
+		return nil, fmt.Errorf("could not query texture: %v", err)
+	}
+
+	return &texture{t: t, w: int(w), h: int(h)}, nil
+}
+
+// LoadFont implements render.Renderer
+func (s *Renderer) LoadFont(data []byte, size int) (render.Font, error) {
+	rw, err := res.NewRWops(data)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := ttf.OpenFontRW(rw, 1, size)
+	if err != nil {
+		return nil, fmt.Errorf("could not open font: %v", err)
+	}
+
+	return &font{f: f}, nil
+}
+
+// TextTexture implements render.Renderer
+func (s *Renderer) TextTexture(f render.Font, text string, outline int, solid bool) (render.Texture, error) {
+	font, ok := f.(*font)
+	if !ok {
+		return nil, fmt.Errorf("font %T is not loaded by SDL renderer", f)
+	}
+
+	surface, err := font.render(text, outline, solid)
+	if err != nil {
+		return nil, err
+	}
+	defer surface.Free()
+
+	t, err := s.r.CreateTextureFromSurface(surface)
+	if err != nil {
+		return nil, fmt.Errorf("cound not create texture: %v", err)
+	}
+	t.SetBlendMode(sdl.BLENDMODE_BLEND)
+
+	return &texture{t: t, w: int(surface.W), h: int(surface.H)}, nil
+}
+
+// NewTarget implements render.Renderer
+func (s *Renderer) NewTarget(w, h int) (render.Texture, error) {
+	t, err := s.r.CreateTexture(sdl.PIXELFORMAT_RGBA8888, sdl.TEXTUREACCESS_TARGET, int32(w), int32(h))
+	if err != nil {
+		return nil, fmt.Errorf("could not create texture: %v", err)
+	}
+	t.SetBlendMode(sdl.BLENDMODE_BLEND)
+
+	return &texture{t: t, w: w, h: h}, nil
+}
+
+// SetTarget implements render.Renderer
+func (s *Renderer) SetTarget(t render.Texture) error {
+	if t == nil {
+		return s.r.SetRenderTarget(nil)
+	}
+
+	texture, err := s.texture(t)
+	if err != nil {
+		return err
+	}
+	return s.r.SetRenderTarget(texture)
+}
+
+// Clear implements render.Renderer
+func (s *Renderer) Clear(c color.NRGBA) error {
+	s.r.SetDrawColor(c.R, c.G, c.B, c.A)
+	return s.r.Clear()
+}
+
+// DrawTexture implements render.Renderer
+func (s *Renderer) DrawTexture(t render.Texture, src, dst *image.Rectangle) error {
+	texture, err := s.texture(t)
+	if err != nil {
+		return err
+	}
+	return s.r.Copy(texture, rect(src), rect(dst))
+}
+
+// DrawSprite implements render.Renderer
+func (s *Renderer) DrawSprite(t render.Texture, src, dst *image.Rectangle, angle float64, flip render.Flip) error {
+	texture, err := s.texture(t)
+	if err != nil {
+		return err
+	}
+
+	var f sdl.RendererFlip = sdl.FLIP_NONE
+	if flip&render.FlipHorizontal != 0 {
+		f |= sdl.FLIP_HORIZONTAL
+	}
+	if flip&render.FlipVertical != 0 {
+		f |= sdl.FLIP_VERTICAL
+	}
+	return s.r.CopyEx(texture, rect(src), rect(dst), angle, nil, f)
+}
+
+// FillRect implements render.Renderer
+func (s *Renderer) FillRect(r *image.Rectangle, c color.NRGBA) error {
+	s.r.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	s.r.SetDrawColor(c.R, c.G, c.B, c.A)
+	return s.r.FillRect(rect(r))
+}
+
+func (s *Renderer) texture(t render.Texture) (*sdl.Texture, error) {
+	texture, ok := t.(*texture)
+	if !ok {
+		return nil, fmt.Errorf("texture %T is not created by SDL renderer", t)
+	}
+	return texture.t, nil
+}
+
+// rect converts r to SDL rectangle, nil stays nil
+func rect(r *image.Rectangle) *sdl.Rect {
+	if r == nil {
+		return nil
+	}
+	return &sdl.Rect{X: int32(r.Min.X), Y: int32(r.Min.Y), W: int32(r.Dx()), H: int32(r.Dy())}
+}
+
+func (t *texture) Size() (w, h int) {
+	return t.w, t.h
+}
+
+func (t *texture) SetTint(c color.NRGBA) {
+	t.t.SetColorMod(c.R, c.G, c.B)
+	t.t.SetAlphaMod(c.A)
+}
+
+func (t *texture) Destroy() {
+	t.t.Destroy()
+}
+
+// Size implements render.Font
+func (f *font) Size(text string) (w, h int, err error) {
+	w, h, err = f.f.SizeUTF8(text)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not measure text: %v", err)
+	}
+	return w, h, nil
+}
+
+// LineSkip implements render.Font
+func (f *font) LineSkip() int {
+	return f.f.LineSkip()
+}
+
+// Close implements render.Font
+func (f *font) Close() {
+	f.f.Close()
+}
+
+// render renders text to a new surface
+func (f *font) render(text string, outline int, solid bool) (*sdl.Surface, error) {
+	// the font may be shared, so its outline is restored right after rendering
+	if outline > 0 {
+		prev := f.f.GetOutline()
+		f.f.SetOutline(outline)
+		defer f.f.SetOutline(prev)
+	}
+
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	var s *sdl.Surface
+	var err error
+	if solid {
+		s, err = f.f.RenderUTF8_Solid(text, white)
+	} else {
+		s, err = f.f.RenderUTF8_Blended(text, white)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not render text: %v", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,29 @@
+package replay
+
+import (
+	"github.com/spoof/go-flappybird/world"
+)
+
+// Player feeds recorded inputs back tick by tick
+type Player struct {
+	rep  *Replay
+	tick int
+	flap int
+}
+
+// NewPlayer creates new Player of rep
+func NewPlayer(rep *Replay) *Player {
+	return &Player{rep: rep}
+}
+
+// Next returns input of the next tick
+func (p *Player) Next() world.Input {
+	var in world.Input
+	if p.flap < len(p.rep.Flaps) && p.rep.Flaps[p.flap] == p.tick {
+		in.Flap = true
+		p.flap++
+	}
+	p.tick++
+
+	return in
+}
@@ -0,0 +1,33 @@
+package replay
+
+import (
+	"github.com/spoof/go-flappybird/world"
+)
+
+// Recorder records inputs of a game run
+type Recorder struct {
+	rep *Replay
+}
+
+// NewRecorder creates new Recorder for the world created with given seed and config
+func NewRecorder(seed int64, cfg world.Config) *Recorder {
+	return &Recorder{
+		rep: &Replay{Version: Version, Seed: seed, World: cfg},
+	}
+}
+
+// Record records input of the next tick
+func (rec *Recorder) Record(in world.Input) {
+	if in.Flap {
+		rec.rep.Flaps = append(rec.rep.Flaps, rec.rep.Ticks)
+	}
+	rec.rep.Ticks++
+}
+
+// Replay returns the recorded replay with given final score
+func (rec *Recorder) Replay(score int) *Replay {
+	rep := *rec.rep
+	rep.Score = score
+	rep.Flaps = append([]int(nil), rec.rep.Flaps...)
+	return &rep
+}
@@ -0,0 +1,100 @@
+// Package replay records inputs of a game run and plays them back.
+//
+// A replay file is a JSON document holding the format version, the seed and configuration
+// of the world and the ticks at which the bird flapped. Since the world is deterministic,
+// this is enough to reproduce a run exactly.
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spoof/go-flappybird/world"
+)
+
+// Version is the version of replay format written by this package. Replays of other
+// versions can not be played back, since the world they were recorded in behaved differently.
+const Version = 2
+
+// Replay is a recorded game run
+type Replay struct {
+	Version int          `json:"version"`
+	Seed    int64        `json:"seed"`
+	World   world.Config `json:"world"`
+	Ticks   int          `json:"ticks"`
+	Flaps   []int        `json:"flaps"`
+	Score   int          `json:"score"`
+}
+
+// Read reads replay from r
+func Read(r io.Reader) (*Replay, error) {
+	var rep Replay
+	if err := json.NewDecoder(r).Decode(&rep); err != nil {
+		return nil, fmt.Errorf("could not decode replay: %v", err)
+	}
+
+	if rep.Version != Version {
+		return nil, fmt.Errorf("unsupported replay version %d", rep.Version)
+	}
+
+	if err := rep.World.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid world of replay: %v", err)
+	}
+
+	prev := -1
+	for _, tick := range rep.Flaps {
+		if tick <= prev {
+			return nil, fmt.Errorf("flap ticks must be increasing and not negative, got %d after %d", tick, prev)
+		}
+		prev = tick
+	}
+	if prev >= rep.Ticks {
+		return nil, fmt.Errorf("flap at tick %d is after the run of %d ticks", prev, rep.Ticks)
+	}
+
+	return &rep, nil
+}
+
+// Load reads replay from the file
+func Load(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open replay: %v", err)
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Write writes replay to w
+func (rep *Replay) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rep); err != nil {
+		return fmt.Errorf("could not encode replay: %v", err)
+	}
+
+	return nil
+}
+
+// Save writes replay to the file
+func (rep *Replay) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create directory: %v", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create replay file: %v", err)
+	}
+
+	if err := rep.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,100 @@
+package replay
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spoof/go-flappybird/world"
+	"github.com/spoof/go-flappybird/world/worldtest"
+)
+
+func TestRecordAndPlay(t *testing.T) {
+	const seed = 3
+	cfg := worldtest.Config()
+
+	w := world.New(cfg, rand.New(rand.NewSource(seed)))
+	rec := NewRecorder(seed, cfg)
+	for i := 0; !w.IsFinished(); i++ {
+		in := world.Input{Flap: i%30 == 0 && i < 600}
+		rec.Record(in)
+		w.Step(in)
+	}
+
+	var buf bytes.Buffer
+	if err := rec.Replay(w.Score).Write(&buf); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	rep, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+
+	played := world.New(rep.World, rand.New(rand.NewSource(rep.Seed)))
+	p := NewPlayer(rep)
+	for i := 0; i < rep.Ticks; i++ {
+		played.Step(p.Next())
+	}
+
+	if !played.IsFinished() {
+		t.Errorf("played world isn't finished after %d ticks", rep.Ticks)
+	}
+	if played.Score != rep.Score || rep.Score != w.Score {
+		t.Errorf("played score is %d, replay score is %d, want %d", played.Score, rep.Score, w.Score)
+	}
+	if !reflect.DeepEqual(played.Bird, w.Bird) {
+		t.Errorf("played bird %+v differs from recorded %+v", played.Bird, w.Bird)
+	}
+}
+
+func TestRead(t *testing.T) {
+	valid := func() *Replay {
+		return &Replay{Version: Version, Seed: 1, World: worldtest.Config(), Ticks: 100, Flaps: []int{0, 10, 20}}
+	}
+
+	tests := []struct {
+		name  string
+		edit  func(rep *Replay)
+		valid bool
+	}{
+		{name: "valid", edit: func(rep *Replay) {}, valid: true},
+		{name: "no flaps", edit: func(rep *Replay) { rep.Flaps = nil }, valid: true},
+		{name: "old version", edit: func(rep *Replay) { rep.Version = Version - 1 }},
+		{name: "zero tick rate", edit: func(rep *Replay) { rep.World.Physics.TickRate = 0 }},
+		{name: "no world", edit: func(rep *Replay) { rep.World = world.Config{} }},
+		{name: "bird outside world", edit: func(rep *Replay) { rep.World.BirdX = rep.World.Width }},
+		{name: "negative gravity", edit: func(rep *Replay) { rep.World.Physics.Gravity = -1 }},
+		{name: "negative flap", edit: func(rep *Replay) { rep.Flaps = []int{-1, 10} }},
+		{name: "repeated flap", edit: func(rep *Replay) { rep.Flaps = []int{0, 10, 10} }},
+		{name: "unordered flaps", edit: func(rep *Replay) { rep.Flaps = []int{10, 0} }},
+		{name: "flap after run", edit: func(rep *Replay) { rep.Flaps = []int{0, 10, rep.Ticks} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep := valid()
+			tt.edit(rep)
+
+			var buf bytes.Buffer
+			if err := rep.Write(&buf); err != nil {
+				t.Fatalf("Write() error: %v", err)
+			}
+
+			_, err := Read(&buf)
+			if tt.valid && err != nil {
+				t.Errorf("Read() error: %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("Read() has accepted invalid replay")
+			}
+		})
+	}
+}
+
+func TestReadMalformed(t *testing.T) {
+	if _, err := Read(strings.NewReader(`{"version": `)); err == nil {
+		t.Errorf("Read() has accepted malformed replay")
+	}
+}
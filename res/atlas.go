@@ -0,0 +1,93 @@
+package res
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+
+	"github.com/spoof/go-flappybird/animation"
+)
+
+// Atlas is a texture holding several sprites. It's described by a JSON file with name of the
+// image, rectangles of frames and animation clips made of the frames:
+//
+//	{
+//	  "image": "imgs/bird.png",
+//	  "frames": {"bird_1": {"x": 0, "y": 0, "w": 50, "h": 43}},
+//	  "clips": {"fly": {"mode": "loop", "frames": [{"frame": "bird_1", "duration": 100}]}}
+//	}
+type Atlas struct {
+	Texture *Texture
+	Frames  map[string]image.Rectangle
+	Clips   map[string]animation.Clip
+}
+
+type atlasFile struct {
+	Image  string                    `json:"image"`
+	Frames map[string]frameRect      `json:"frames"`
+	Clips  map[string]animation.Clip `json:"clips"`
+}
+
+type frameRect struct {
+	X, Y, W, H int
+}
+
+// Atlas loads the atlas described by the file. Its texture is shared like any other texture of
+// the manager, the atlas must be released when it's not needed.
+func (m *Manager) Atlas(name string) (*Atlas, error) {
+	data, err := ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var f atlasFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("could not decode atlas %s: %v", name, err)
+	}
+
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("invalid atlas %s: %v", name, err)
+	}
+
+	texture, err := m.Texture(f.Image)
+	if err != nil {
+		return nil, fmt.Errorf("could not load atlas image: %v", err)
+	}
+
+	frames := make(map[string]image.Rectangle, len(f.Frames))
+	for name, r := range f.Frames {
+		frames[name] = image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+	}
+
+	return &Atlas{Texture: texture, Frames: frames, Clips: f.Clips}, nil
+}
+
+// Release releases texture of the atlas
+func (a *Atlas) Release() {
+	a.Texture.Release()
+}
+
+func (f atlasFile) validate() error {
+	if f.Image == "" {
+		return fmt.Errorf("no image")
+	}
+
+	for name, rect := range f.Frames {
+		if rect.W <= 0 || rect.H <= 0 {
+			return fmt.Errorf("frame %s is empty", name)
+		}
+	}
+
+	for name, c := range f.Clips {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("clip %s: %v", name, err)
+		}
+		for _, frame := range c.Frames {
+			if _, ok := f.Frames[frame.Name]; !ok {
+				return fmt.Errorf("clip %s: unknown frame %s", name, frame.Name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package res
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/spoof/go-flappybird/render"
+)
+
+// Manager loads textures and fonts once and shares them between scenes. Every handle got from the
+// manager must be released, a resource is freed when its last handle is released. Resources are
+// SDL objects, so the manager is used from the main thread only.
+type Manager struct {
+	r        render.Renderer
+	theme    *Theme
+	textures map[string]*textureEntry
+	fonts    map[fontKey]*fontEntry
+}
+
+type textureEntry struct {
+	texture render.Texture
+	refs    int
+}
+
+type fontKey struct {
+	name string
+	size int
+}
+
+type fontEntry struct {
+	font render.Font
+	refs int
+}
+
+// Texture is a shared handle of a texture
+type Texture struct {
+	render.Texture
+
+	m        *Manager
+	name     string
+	released bool
+}
+
+// Font is a shared handle of a font
+type Font struct {
+	render.Font
+
+	m        *Manager
+	key      fontKey
+	released bool
+}
+
+// NewManager creates new Manager which creates textures for r and tells scenes to use theme
+func NewManager(r render.Renderer, theme *Theme) *Manager {
+	return &Manager{
+		r:        r,
+		theme:    theme,
+		textures: make(map[string]*textureEntry),
+		fonts:    make(map[fontKey]*fontEntry),
+	}
+}
+
+// Theme returns theme of the game
+func (m *Manager) Theme() *Theme {
+	return m.theme
+}
+
+// Texture returns handle of the image file, loading it on the first use
+func (m *Manager) Texture(name string) (*Texture, error) {
+	e, ok := m.textures[name]
+	if !ok {
+		texture, err := LoadTexture(m.r, name)
+		if err != nil {
+			return nil, err
+		}
+		e = &textureEntry{texture: texture}
+		m.textures[name] = e
+	}
+	e.refs++
+
+	return &Texture{Texture: e.texture, m: m, name: name}, nil
+}
+
+// Font returns handle of the font file at point size, loading it on the first use
+func (m *Manager) Font(name string, size int) (*Font, error) {
+	key := fontKey{name: name, size: size}
+	e, ok := m.fonts[key]
+	if !ok {
+		font, err := LoadFont(m.r, name, size)
+		if err != nil {
+			return nil, err
+		}
+		e = &fontEntry{font: font}
+		m.fonts[key] = e
+	}
+	e.refs++
+
+	return &Font{Font: e.font, m: m, key: key}, nil
+}
+
+// Release releases the handle. The texture is destroyed when all its handles are released.
+func (t *Texture) Release() {
+	if t.released {
+		log.Printf("texture %s is released twice", t.name)
+		return
+	}
+	t.released = true
+
+	e, ok := t.m.textures[t.name]
+	if !ok {
+		// the manager is already closed
+		return
+	}
+	e.refs--
+	if e.refs == 0 {
+		e.texture.Destroy()
+		delete(t.m.textures, t.name)
+	}
+}
+
+// Destroy is the same as Release. It hides Destroy of the texture, so a shared texture isn't
+// destroyed while it's used by others.
+func (t *Texture) Destroy() {
+	t.Release()
+}
+
+// Release releases the handle. The font is closed when all its handles are released.
+func (f *Font) Release() {
+	if f.released {
+		log.Printf("font %s:%d is released twice", f.key.name, f.key.size)
+		return
+	}
+	f.released = true
+
+	e, ok := f.m.fonts[f.key]
+	if !ok {
+		// the manager is already closed
+		return
+	}
+	e.refs--
+	if e.refs == 0 {
+		e.font.Close()
+		delete(f.m.fonts, f.key)
+	}
+}
+
+// Close is the same as Release. It hides Close of the font, so a shared font isn't closed while
+// it's used by others.
+func (f *Font) Close() {
+	f.Release()
+}
+
+// Close frees all resources. It returns an error listing resources which still have handles,
+// they are leaked by their users.
+func (m *Manager) Close() error {
+	var leaks []string
+	for name, e := range m.textures {
+		leaks = append(leaks, fmt.Sprintf("texture %s (%d handles)", name, e.refs))
+		e.texture.Destroy()
+	}
+	for key, e := range m.fonts {
+		leaks = append(leaks, fmt.Sprintf("font %s:%d (%d handles)", key.name, key.size, e.refs))
+		e.font.Close()
+	}
+	m.textures = make(map[string]*textureEntry)
+	m.fonts = make(map[fontKey]*fontEntry)
+
+	if len(leaks) == 0 {
+		return nil
+	}
+
+	sort.Strings(leaks)
+	return fmt.Errorf("resources were not released: %s", strings.Join(leaks, ", "))
+}
@@ -0,0 +1,102 @@
+// Package res holds images, fonts, sounds and music of the game. They are embedded into the
+// binary, so the game runs from any directory. Any file can be replaced by a file with the same
+// name in the current theme or in the override directory.
+package res
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/spoof/go-flappybird/render"
+)
+
+//go:embed fonts imgs music sounds theme.json
+var embedded embed.FS
+
+var (
+	mu          sync.Mutex
+	overrideDir string
+	themeFS     fs.FS
+
+	// files keeps contents of all opened files, so they are read once
+	files = make(map[string][]byte)
+)
+
+// SetOverrideDir makes files in dir take precedence over theme and embedded ones. Files already
+// opened are not reloaded.
+func SetOverrideDir(dir string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	overrideDir = dir
+}
+
+// ReadFile returns contents of the file, e.g. "imgs/pipe.png"
+func ReadFile(name string) ([]byte, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if data, ok := files[name]; ok {
+		return data, nil
+	}
+
+	data, err := readFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s is empty", name)
+	}
+
+	files[name] = data
+	return data, nil
+}
+
+func readFile(name string) ([]byte, error) {
+	if overrideDir != "" {
+		data, err := os.ReadFile(filepath.Join(overrideDir, filepath.FromSlash(name)))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if themeFS != nil {
+		data, err := fs.ReadFile(themeFS, name)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return embedded.ReadFile(name)
+}
+
+// LoadTexture loads image file as a texture of r
+func LoadTexture(r render.Renderer, name string) (render.Texture, error) {
+	data, err := ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LoadTexture(data)
+}
+
+// LoadFont loads font file at point size for r
+func LoadFont(r render.Renderer, name string, size int) (render.Font, error) {
+	data, err := ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LoadFont(data, size)
+}
@@ -0,0 +1,73 @@
+package res
+
+// #cgo windows LDFLAGS: -lSDL2
+// #cgo linux freebsd darwin pkg-config: sdl2
+// #if defined(_WIN32)
+// 	#include <SDL2/SDL.h>
+// #else
+// 	#include <SDL.h>
+// #endif
+// #include <string.h>
+//
+// static int closeCopy(SDL_RWops *rw) {
+// 	void *mem = rw->hidden.mem.base;
+// 	SDL_FreeRW(rw);
+// 	SDL_free(mem);
+// 	return 0;
+// }
+//
+// // rwFromCopy returns stream reading a copy of data, which is freed when the stream is closed
+// static SDL_RWops *rwFromCopy(const void *data, int size) {
+// 	void *mem = SDL_malloc(size);
+// 	if (mem == NULL) {
+// 		SDL_OutOfMemory();
+// 		return NULL;
+// 	}
+// 	memcpy(mem, data, size);
+//
+// 	SDL_RWops *rw = SDL_RWFromConstMem(mem, size);
+// 	if (rw == NULL) {
+// 		SDL_free(mem);
+// 		return NULL;
+// 	}
+// 	rw->close = closeCopy;
+// 	return rw;
+// }
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// RWops returns SDL stream reading the file
+func RWops(name string) (*sdl.RWops, error) {
+	data, err := ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rw, err := NewRWops(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return rw, nil
+}
+
+// NewRWops returns SDL stream reading data. SDL reads fonts and music lazily and keeps the
+// stream, so the stream reads a copy of data in C memory, which is freed when the stream is
+// closed.
+func NewRWops(data []byte) (*sdl.RWops, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data")
+	}
+
+	rw := C.rwFromCopy(unsafe.Pointer(&data[0]), C.int(len(data)))
+	if rw == nil {
+		return nil, fmt.Errorf("could not create stream: %v", sdl.GetError())
+	}
+
+	return (*sdl.RWops)(unsafe.Pointer(rw)), nil
+}
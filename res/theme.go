@@ -0,0 +1,172 @@
+package res
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ThemeFile is name of the manifest in the root of a theme
+const ThemeFile = "theme.json"
+
+// Theme describes look of the game. File names are relative to the root of the theme. Files
+// missing in the theme are taken from the built-in one.
+type Theme struct {
+	Name       string    `json:"name"`
+	Background string    `json:"background"`
+	Pipe       string    `json:"pipe"`
+	Bird       BirdTheme `json:"bird"`
+	Fonts      Fonts     `json:"fonts"`
+}
+
+// BirdTheme describes animation of the bird. It's either a list of images looped in order, or a
+// sprite atlas with "fly" clip and optional "fall" clip played after the bird has crashed. The
+// list is used if it isn't empty.
+type BirdTheme struct {
+	Frames []Frame `json:"frames"`
+	Atlas  string  `json:"atlas"`
+}
+
+// Frame is a single image of the bird animation
+type Frame struct {
+	Image string `json:"image"`
+
+	// Duration of the frame in milliseconds
+	Duration int `json:"duration"`
+}
+
+// Fonts of the theme
+type Fonts struct {
+	// Title is used for captions and scores
+	Title string `json:"title"`
+	// Text is used for hints and lists
+	Text string `json:"text"`
+}
+
+// themeCloser closes archive of the current theme
+var themeCloser io.Closer
+
+// SetTheme makes files of the theme at path take precedence over built-in ones and returns
+// manifest of the theme. The theme is either a directory or a zip archive with theme.json in its
+// root. Empty path selects the built-in theme. Files already opened are not reloaded.
+func SetTheme(path string) (*Theme, error) {
+	theme, err := builtinTheme()
+	if err != nil {
+		return nil, err
+	}
+
+	var fsys fs.FS
+	var closer io.Closer
+	if path != "" {
+		fsys, closer, err = openTheme(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not open theme: %v", err)
+		}
+
+		if err := loadTheme(fsys, theme); err != nil {
+			if closer != nil {
+				closer.Close()
+			}
+			return nil, err
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if themeCloser != nil {
+		themeCloser.Close()
+	}
+	themeFS, themeCloser = fsys, closer
+
+	return theme, nil
+}
+
+// FindThemes returns paths of themes in dir, i.e. its subdirectories and zip archives. Missing
+// directory has no themes.
+func FindThemes(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(strings.ToLower(e.Name()), ".zip") {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	return paths, nil
+}
+
+func builtinTheme() (*Theme, error) {
+	theme := &Theme{}
+	if err := loadTheme(embedded, theme); err != nil {
+		return nil, fmt.Errorf("built-in theme: %v", err)
+	}
+	return theme, nil
+}
+
+func openTheme(path string) (fs.FS, io.Closer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if info.IsDir() {
+		return os.DirFS(path), nil, nil
+	}
+
+	if !strings.HasSuffix(strings.ToLower(path), ".zip") {
+		return nil, nil, fmt.Errorf("%s is neither a directory nor a zip archive", path)
+	}
+
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return z, z, nil
+}
+
+// loadTheme reads manifest from fsys on top of theme
+func loadTheme(fsys fs.FS, theme *Theme) error {
+	data, err := fs.ReadFile(fsys, ThemeFile)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, theme); err != nil {
+		return fmt.Errorf("could not decode %s: %v", ThemeFile, err)
+	}
+
+	return theme.validate()
+}
+
+func (t *Theme) validate() error {
+	if t.Background == "" || t.Pipe == "" || t.Fonts.Title == "" || t.Fonts.Text == "" {
+		return fmt.Errorf("theme %q misses background, pipe or fonts", t.Name)
+	}
+
+	if len(t.Bird.Frames) == 0 && t.Bird.Atlas == "" {
+		return fmt.Errorf("theme %q has neither bird frames nor atlas", t.Name)
+	}
+
+	for i, f := range t.Bird.Frames {
+		if f.Image == "" {
+			return fmt.Errorf("bird frame %d has no image", i+1)
+		}
+		if f.Duration <= 0 {
+			return fmt.Errorf("bird frame %d must have positive duration, got %d", i+1, f.Duration)
+		}
+	}
+
+	return nil
+}
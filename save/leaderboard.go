@@ -0,0 +1,59 @@
+package save
+
+import (
+	"time"
+)
+
+// LeaderboardSize is the number of best runs kept in the leaderboard
+const LeaderboardSize = 10
+
+// Entry is a run in the leaderboard
+type Entry struct {
+	Name  string    `json:"name"`
+	Date  time.Time `json:"date"`
+	Seed  int64     `json:"seed"`
+	Score int       `json:"score"`
+}
+
+// Qualifies reports whether a run with the score gets into the leaderboard
+func (d *Data) Qualifies(score int) bool {
+	if score <= 0 {
+		return false
+	}
+
+	if len(d.Leaderboard) < LeaderboardSize {
+		return true
+	}
+
+	return score > d.Leaderboard[len(d.Leaderboard)-1].Score
+}
+
+// AddEntry adds the run to the leaderboard and updates the best score. It returns position
+// of the entry in the leaderboard, or -1 if it doesn't qualify.
+func (d *Data) AddEntry(e Entry) int {
+	if e.Score > d.BestScore {
+		d.BestScore = e.Score
+	}
+
+	if !d.Qualifies(e.Score) {
+		return -1
+	}
+
+	pos := len(d.Leaderboard)
+	for i, other := range d.Leaderboard {
+		if e.Score > other.Score {
+			pos = i
+			break
+		}
+	}
+
+	d.Leaderboard = append(d.Leaderboard, Entry{})
+	copy(d.Leaderboard[pos+1:], d.Leaderboard[pos:])
+	d.Leaderboard[pos] = e
+
+	if len(d.Leaderboard) > LeaderboardSize {
+		d.Leaderboard = d.Leaderboard[:LeaderboardSize]
+	}
+
+	return pos
+}
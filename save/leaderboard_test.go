@@ -0,0 +1,56 @@
+package save
+
+import (
+	"testing"
+)
+
+// fullData returns data with full leaderboard of scores 20, 18, ..., 2
+func fullData() *Data {
+	d := &Data{BestScore: 20}
+	for i := 0; i < LeaderboardSize; i++ {
+		d.Leaderboard = append(d.Leaderboard, Entry{Score: 2 * (LeaderboardSize - i)})
+	}
+	return d
+}
+
+func TestDataAddEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  *Data
+		score int
+		pos   int
+		best  int
+	}{
+		{"first run", &Data{}, 3, 0, 3},
+		{"zero score", &Data{}, 0, -1, 0},
+		{"best run", fullData(), 25, 0, 25},
+		{"middle run", fullData(), 15, 3, 20},
+		{"tie", fullData(), 16, 3, 20},
+		{"last run", fullData(), 3, 9, 20},
+		{"too low", fullData(), 2, -1, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.AddEntry(Entry{Name: "new", Score: tt.score}); got != tt.pos {
+				t.Errorf("AddEntry() = %d, want %d", got, tt.pos)
+			}
+			if tt.data.BestScore != tt.best {
+				t.Errorf("best score is %d, want %d", tt.data.BestScore, tt.best)
+			}
+
+			lb := tt.data.Leaderboard
+			if len(lb) > LeaderboardSize {
+				t.Errorf("leaderboard has %d entries, want at most %d", len(lb), LeaderboardSize)
+			}
+			for i := 1; i < len(lb); i++ {
+				if lb[i].Score > lb[i-1].Score {
+					t.Errorf("leaderboard isn't sorted: %d after %d", lb[i].Score, lb[i-1].Score)
+				}
+			}
+			if tt.pos >= 0 && lb[tt.pos].Name != "new" {
+				t.Errorf("entry %d is %+v, want the new one", tt.pos, lb[tt.pos])
+			}
+		})
+	}
+}
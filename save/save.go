@@ -0,0 +1,109 @@
+// Package save keeps player's progress between launches of the game.
+package save
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Version is the version of save data schema written by this package
+const Version = 2
+
+// Data is the progress of the player
+type Data struct {
+	Version     int     `json:"version"`
+	BestScore   int     `json:"best_score"`
+	Leaderboard []Entry `json:"leaderboard"`
+}
+
+// Store keeps Data in a file
+type Store struct {
+	path string
+}
+
+// NewStore creates new Store keeping data in the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Load reads data from the store. If nothing is saved yet, it returns empty data.
+func (s *Store) Load() (*Data, error) {
+	raw, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &Data{Version: Version}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read save data: %v", err)
+	}
+
+	var d Data
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return nil, fmt.Errorf("could not decode save data: %v", err)
+	}
+
+	if err := migrate(&d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// Save writes data to the store. The file is replaced atomically, so it's never left
+// half-written.
+func (s *Store) Save(d *Data) error {
+	d.Version = Version
+	raw, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode save data: %v", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("could not create directory: %v", err)
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temporary file: %v", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write save data: %v", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write save data: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not write save data: %v", err)
+	}
+
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("could not replace save data: %v", err)
+	}
+
+	return nil
+}
+
+// migrate upgrades data of older schema versions to the current one
+func migrate(d *Data) error {
+	if d.Version > Version {
+		return fmt.Errorf("save data version %d is newer than supported %d", d.Version, Version)
+	}
+
+	switch d.Version {
+	case 1:
+		// leaderboard appeared in version 2, runs of version 1 are lost
+		d.Leaderboard = nil
+	case 2:
+	default:
+		return fmt.Errorf("unknown save data version %d", d.Version)
+	}
+
+	d.Version = Version
+	return nil
+}
@@ -0,0 +1,93 @@
+package save
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStoreLoadMissing(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "save.json"))
+
+	d, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if want := (&Data{Version: Version}); !reflect.DeepEqual(d, want) {
+		t.Errorf("Load() = %+v, want %+v", d, want)
+	}
+}
+
+func TestStoreSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "save.json")
+	s := NewStore(path)
+
+	date := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	d := &Data{BestScore: 12, Leaderboard: []Entry{{Name: "bird", Date: date, Seed: 42, Score: 12}}}
+	if err := s.Save(d); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := s.Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, d) {
+		t.Errorf("Load() = %+v, want %+v", loaded, d)
+	}
+
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("directory has %v, want only the save file", files)
+	}
+}
+
+func TestStoreLoadVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *Data
+	}{
+		{
+			name: "version 1",
+			data: `{"version": 1, "best_score": 5, "leaderboard": [{"name": "old", "score": 5}]}`,
+			want: &Data{Version: Version, BestScore: 5},
+		},
+		{
+			name: "current version",
+			data: `{"version": 2, "best_score": 5, "leaderboard": [{"name": "new", "score": 5}]}`,
+			want: &Data{Version: Version, BestScore: 5, Leaderboard: []Entry{{Name: "new", Score: 5}}},
+		},
+		{name: "newer version", data: `{"version": 3}`},
+		{name: "no version", data: `{"best_score": 5}`},
+		{name: "malformed", data: `{"version": `},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "save.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			d, err := NewStore(path).Load()
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("Load() has accepted %s", tt.data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if !reflect.DeepEqual(d, tt.want) {
+				t.Errorf("Load() = %+v, want %+v", d, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package scene
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/ui"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Controls is the scene where player binds actions to keys and buttons. The scene itself is
+// navigated with fixed keys, so player can't lock themselves out by a wrong binding. Escape
+// cancels waiting for a new binding, so it can't be bound to more actions than it's bound to
+// by default.
+type Controls struct {
+	Base
+
+	width  int
+	height int
+
+	bg        *res.Texture
+	titleFont *res.Font
+	rowFont   *res.Font
+	hintFont  *res.Font
+	screen    *ui.Screen
+	list      *ui.List
+	message   *ui.Label
+
+	mapper *input.Mapper
+	path   string
+
+	bindings  input.Bindings
+	capturing bool
+}
+
+// NewControls creates new Controls scene which changes bindings of mapper and saves them to
+// the file at path
+func NewControls(rm *res.Manager, width, height int, mapper *input.Mapper, path string) (*Controls, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	bg, err := rm.Texture(rm.Theme().Background)
+	if err != nil {
+		return nil, fmt.Errorf("could not load background image: %v", err)
+	}
+	acquired = append(acquired, bg.Release)
+
+	titleFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, titleFont.Release)
+
+	rowFont, err := rm.Font(rm.Theme().Fonts.Text, 20)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, rowFont.Release)
+
+	hintFont, err := rm.Font(rm.Theme().Fonts.Text, 14)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, hintFont.Release)
+
+	c := &Controls{
+		width:     width,
+		height:    height,
+		bg:        bg,
+		titleFont: titleFont,
+		rowFont:   rowFont,
+		hintFont:  hintFont,
+		screen:    ui.NewScreen(width, height),
+		message:   &ui.Label{Layout: ui.Layout{Anchor: ui.Bottom, Y: -110}, Font: rowFont},
+		mapper:    mapper,
+		path:      path,
+	}
+
+	c.list = &ui.List{
+		Layout:     ui.Layout{Anchor: ui.Top, Y: 150},
+		Font:       rowFont,
+		RowHeight:  50,
+		OnActivate: c.capture,
+	}
+	c.screen.Add(
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 40}, Font: titleFont, Text: "Controls"},
+		c.list,
+		c.message,
+		&ui.Label{
+			Layout: ui.Layout{Anchor: ui.Bottom, Y: -46},
+			Font:   hintFont,
+			Text:   "Up/Down: select   Enter: add   Delete: clear   R: defaults   Esc: save and exit",
+		},
+	)
+
+	ok = true
+	return c, nil
+}
+
+// Enter implements Scene. It shows the current bindings of the mapper.
+func (c *Controls) Enter() {
+	c.bindings = c.mapper.Bindings()
+	c.list.Selected = 0
+	c.capturing = false
+	c.message.Text = ""
+}
+
+// Capturing implements Scene. It reports whether the scene waits for a new binding.
+func (c *Controls) Capturing() bool {
+	return c.capturing
+}
+
+// HandleEvent implements Scene
+func (c *Controls) HandleEvent(e input.Event) Event {
+	if done := c.handleEvent(e); done {
+		return &PopEvent{}
+	}
+	return nil
+}
+
+// Destroy frees all resources
+func (c *Controls) Destroy() {
+	c.bg.Release()
+	c.titleFont.Release()
+	c.rowFont.Release()
+	c.hintFont.Release()
+	c.screen.Destroy()
+}
+
+func (c *Controls) handleEvent(e input.Event) (done bool) {
+	if c.capturing {
+		binding, ok := input.BindingOf(e.SDL)
+		if !ok {
+			return false
+		}
+
+		c.capturing = false
+		c.message.Text = ""
+		if binding == input.KeyBinding(sdl.K_ESCAPE) {
+			return false
+		}
+		if err := c.bindings.Bind(input.Actions[c.list.Selected], binding); err != nil {
+			c.message.Text = err.Error()
+		}
+		return false
+	}
+
+	if c.screen.HandleEvent(e) {
+		return false
+	}
+
+	switch ui.NavKey(e.SDL) {
+	case sdl.K_DELETE:
+		c.bindings[input.Actions[c.list.Selected]] = nil
+		c.message.Text = ""
+	case sdl.K_r:
+		c.bindings = input.DefaultBindings()
+		c.message.Text = ""
+	case sdl.K_ESCAPE:
+		return c.save()
+	}
+
+	return false
+}
+
+// capture starts waiting for a new binding of i-th action
+func (c *Controls) capture(i int) {
+	c.capturing = true
+	c.message.Text = "Press a key or button for " + input.Actions[i].String() + ", Esc to cancel"
+}
+
+func (c *Controls) save() bool {
+	if err := c.bindings.Validate(); err != nil {
+		c.message.Text = err.Error()
+		return false
+	}
+
+	if err := c.bindings.Save(c.path); err != nil {
+		c.message.Text = "Could not save controls: " + err.Error()
+		return false
+	}
+
+	c.mapper.SetBindings(c.bindings)
+	return true
+}
+
+// Render implements Scene
+func (c *Controls) Render(r render.Renderer) error {
+	if err := r.DrawTexture(c.bg.Texture, nil, nil); err != nil {
+		return fmt.Errorf("could not copy background: %v", err)
+	}
+
+	c.list.Items = make([]string, len(input.Actions))
+	for i, action := range input.Actions {
+		var names []string
+		for _, b := range c.bindings[action] {
+			names = append(names, b.String())
+		}
+
+		name := action.String()
+		c.list.Items[i] = strings.ToUpper(name[:1]) + name[1:] + ": " + strings.Join(names, ", ")
+	}
+
+	if err := c.screen.Paint(r); err != nil {
+		return fmt.Errorf("could not paint controls: %v", err)
+	}
+
+	return nil
+}
@@ -1,16 +1,66 @@
 package scene
 
+import (
+	"github.com/spoof/go-flappybird/config"
+	"github.com/spoof/go-flappybird/replay"
+)
+
 type Event interface{}
 
+// Names scenes are registered with in the scene manager
+const (
+	SplashScene      = "splash"
+	MenuScene        = "menu"
+	ModesScene       = "modes"
+	SettingsScene    = "settings"
+	ControlsScene    = "controls"
+	LeaderboardScene = "leaderboard"
+	GameScene        = "game"
+	PauseScene       = "pause"
+	GameOverScene    = "gameover"
+)
+
+// PushEvent shows Scene above the current scene. The current scene is shown again after
+// PopEvent.
+type PushEvent struct {
+	Scene string
+}
+
+// PopEvent returns to the scene below the current one
+type PopEvent struct{}
+
+// ReplaceEvent replaces the current scene with Scene. All replaces all the scenes, so the stack
+// consists of Scene only. Back tells that player goes back to Scene, e.g. quits to the menu, so
+// the transition is played backwards like after PopEvent.
+type ReplaceEvent struct {
+	Scene string
+	All   bool
+	Back  bool
+}
+
 type QuitEvent struct{}
 
 type ErrorEvent struct {
 	Err error
 }
 
-type StartGameEvent struct{}
+// StartGameEvent starts a new game in Mode. Zero mode keeps mode of the previous game.
+type StartGameEvent struct {
+	Mode Mode
+}
+
+// ApplySettingsEvent asks to apply and save settings player has changed
+type ApplySettingsEvent struct {
+	Config config.Config
+}
+
+type SubmitScoreEvent struct {
+	Name string
+}
 
 type EndGameEvent struct {
 	Score     int
 	BestScore int
+	Seed      int64
+	Replay    *replay.Replay
 }
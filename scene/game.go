@@ -2,243 +2,281 @@ package scene
 
 import (
 	"fmt"
-	"math"
+	"image/color"
+	"math/rand"
 	"strconv"
 	"time"
 
+	"github.com/spoof/go-flappybird/audio"
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/replay"
+	"github.com/spoof/go-flappybird/res"
 	"github.com/spoof/go-flappybird/scene/gameobj"
-	"github.com/veandco/go-sdl2/img"
+	"github.com/spoof/go-flappybird/scene/text"
+	"github.com/spoof/go-flappybird/world"
 	"github.com/veandco/go-sdl2/sdl"
-	"github.com/veandco/go-sdl2/ttf"
 )
 
-const (
-	distanceBetweenPipes = 300
-	birdX                = 200
-)
+// maxLag limits number of ticks made in a single frame, so a slow frame doesn't make the game
+// stall catching up
+const maxLag = 250 * time.Millisecond
 
 // Game is game scene
 type Game struct {
+	Base
+
 	width  int
 	height int
 
-	bg          *sdl.Texture
-	bird        *gameobj.Bird
-	scoreFont   *ttf.Font
-	pipeTexture *sdl.Texture
-	pipeWidth   int
-	pipePairs   []*gameobj.PipePair
-
-	score      int
-	bestScore  int
-	isGameOver bool
+	bg        *res.Texture
+	bird      *gameobj.Bird
+	pipePair  *gameobj.PipePair
+	scoreFont *res.Font
+	text      *text.Renderer
+	audio     *audio.Audio
+
+	worldCfg  world.Config
+	world     *world.World
+	flap      bool
+	bestScore int
+
+	// lag is time not yet simulated by ticks, alpha is its part of a tick frames are
+	// interpolated by
+	lag   time.Duration
+	alpha float64
+
+	seed      int64
+	fixedSeed bool
+
+	recorder *replay.Recorder
+	playback *replay.Replay
+	player   *replay.Player
 }
 
-// NewGame creates new Game scene
-func NewGame(r *sdl.Renderer, width, height int) (*Game, error) {
-	bg, err := img.LoadTexture(r, "res/imgs/background.png")
+// NewGame creates new Game scene with bird at birdX moving according to physics. Sounds of the
+// game are played with a.
+func NewGame(rm *res.Manager, width, height, birdX int, physics world.Physics, a *audio.Audio) (*Game, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	bg, err := rm.Texture(rm.Theme().Background)
 	if err != nil {
 		return nil, fmt.Errorf("could not load background image: %v", err)
 	}
+	acquired = append(acquired, bg.Release)
 
-	bird, err := gameobj.NewBird(r, birdX, height/2)
+	bird, err := gameobj.NewBird(rm)
 	if err != nil {
 		return nil, fmt.Errorf("could not create bird: %v", err)
 	}
+	acquired = append(acquired, bird.Destroy)
 
-	pipe, err := img.LoadTexture(r, "res/imgs/pipe.png")
-	if err != nil {
-		return nil, fmt.Errorf("could not load pipe image: %v", err)
-	}
-
-	_, _, pipeWidth, _, err := pipe.Query()
+	pipePair, err := gameobj.NewPipePair(rm)
 	if err != nil {
-		return nil, fmt.Errorf("could not get pipe width: %v", err)
+		return nil, fmt.Errorf("could not create pipe: %v", err)
 	}
+	acquired = append(acquired, pipePair.Destroy)
 
-	scoreFont, err := ttf.OpenFont("res/fonts/flappy.ttf", 42)
+	scoreFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
 	if err != nil {
 		return nil, fmt.Errorf("cound not load font: %v", err)
 	}
+	acquired = append(acquired, scoreFont.Release)
+
+	worldCfg := world.Config{
+		Width:      width,
+		Height:     height,
+		BirdX:      birdX,
+		BirdWidth:  bird.Width,
+		BirdHeight: bird.Height,
+		PipeWidth:  pipePair.Width,
+		Physics:    physics,
+	}
 
+	ok = true
 	return &Game{
 		width:  width,
 		height: height,
 
-		bg:          bg,
-		bird:        bird,
-		pipeTexture: pipe,
-		pipeWidth:   int(pipeWidth),
-		scoreFont:   scoreFont,
+		bg:        bg,
+		bird:      bird,
+		pipePair:  pipePair,
+		scoreFont: scoreFont,
+		text:      text.NewRenderer(),
+		audio:     a,
+		worldCfg:  worldCfg,
 	}, nil
 }
 
-// Run runs the game scene.
-func (g *Game) Run(in <-chan sdl.Event, r *sdl.Renderer) <-chan Event {
-	out := make(chan Event)
-	go func() {
-		defer close(out)
-
-		g.reset()
-
-		tick := time.Tick(10 * time.Millisecond)
-		for {
-			select {
-			case event, ok := <-in:
-				if !ok {
-					return
-				}
-				g.handleEvent(event)
-			case <-tick:
-				if g.hasCollisions() {
-					g.isGameOver = true
-				}
-
-				if !g.isGameOver {
-					g.generatePipes()
-					g.moveScene()
-					g.updateScore()
-					g.deleteHiddenPipes()
-				} else {
-					g.bird.Fall()
-				}
-
-				g.moveBird()
-
-				if err := g.paint(r); err != nil {
-					out <- &ErrorEvent{Err: err}
-					return
-				}
-
-				if g.doesBirdHitsGround() && g.isGameOver {
-					out <- &EndGameEvent{Score: g.score, BestScore: g.bestScore}
-					return
-				}
-
-			}
+// Enter implements Scene. It prepares a new game.
+func (g *Game) Enter() {
+	g.reset()
+}
+
+// Pause implements Scene. A flap made just before the pause is dropped.
+func (g *Game) Pause() {
+	g.flap = false
+}
+
+// HandleEvent implements Scene. The game is paused when player asks to or the window loses
+// focus.
+func (g *Game) HandleEvent(event input.Event) Event {
+	if event.Actions.Has(input.Pause) {
+		return &PushEvent{Scene: PauseScene}
+	}
 
+	if e, ok := event.SDL.(*sdl.WindowEvent); ok {
+		switch e.Event {
+		case sdl.WINDOWEVENT_FOCUS_LOST, sdl.WINDOWEVENT_MINIMIZED:
+			return &PushEvent{Scene: PauseScene}
 		}
+	}
 
-	}()
-	return out
-}
+	if g.player == nil && event.Actions.Has(input.Flap) {
+		g.flap = true
+	}
 
-// Destroy frees all resources
-func (g *Game) Destroy() {
-	g.bg.Destroy()
-	g.bird.Destroy()
-	g.pipeTexture.Destroy()
+	return nil
 }
 
-func (g *Game) reset() {
-	g.score = 0
-	g.bird.ResetPosition()
-	g.pipePairs = nil
-	g.isGameOver = false
-}
+// Update implements Scene. The world is stepped with a fixed tick rate, time left over is
+// carried to the next update and used to interpolate the frame between the last two ticks.
+func (g *Game) Update(dt time.Duration) Event {
+	tick := time.Second / time.Duration(g.worldCfg.Physics.TickRate)
+	g.lag += dt
+	if g.lag > maxLag {
+		g.lag = maxLag
+	}
 
-func (g *Game) hasCollisions() bool {
-	if g.bird.Y <= 0 {
-		return true
+	for g.lag >= tick && !g.world.IsFinished() {
+		g.step()
+		g.lag -= tick
 	}
+	g.alpha = float64(g.lag) / float64(tick)
 
-	if g.doesBirdHitsGround() {
-		return true
+	g.bird.Update(dt, g.world.IsGameOver())
+
+	if g.world.Score > g.bestScore {
+		g.bestScore = g.world.Score
 	}
 
-	for _, pp := range g.pipePairs {
-		if pp.Hits(g.bird) {
-			return true
+	if g.world.IsFinished() {
+		return &EndGameEvent{
+			Score:     g.world.Score,
+			BestScore: g.bestScore,
+			Seed:      g.seed,
+			Replay:    g.recorder.Replay(g.world.Score),
 		}
-
 	}
 
-	return false
+	return nil
 }
 
-func (g *Game) doesBirdHitsGround() bool {
-	if g.bird.Y+g.bird.Height >= g.height {
-		return true
-	}
+// Render implements Scene
+func (g *Game) Render(r render.Renderer) error {
+	return g.draw(r, g.alpha)
+}
 
-	return false
+// Destroy frees all resources
+func (g *Game) Destroy() {
+	g.bg.Release()
+	g.bird.Destroy()
+	g.pipePair.Destroy()
+	g.scoreFont.Release()
+	g.text.Destroy()
 }
 
-func (g *Game) handleEvent(event sdl.Event) {
-	switch e := event.(type) {
-	case *sdl.MouseButtonEvent:
-		if e.Type != sdl.MOUSEBUTTONDOWN {
-			return
-		}
-		if !g.isGameOver {
-			g.bird.Jump()
-		}
-	}
+// SetSeed makes every following run use pipes generated from seed
+func (g *Game) SetSeed(seed int64) {
+	g.seed = seed
+	g.fixedSeed = true
 }
 
-func (g *Game) generatePipes() {
-	needNewPipe := false
-	if len(g.pipePairs) == 0 {
-		needNewPipe = true
-	} else {
-		lastPipe := g.pipePairs[len(g.pipePairs)-1]
-		if g.width-(lastPipe.X+lastPipe.Width) >= distanceBetweenPipes {
-			needNewPipe = true
-		}
-	}
+// ResetSeed makes every following run use random pipes
+func (g *Game) ResetSeed() {
+	g.fixedSeed = false
+}
 
-	if needNewPipe {
-		x := g.width
-		pipes := gameobj.NewPipePair(g.pipeTexture, x, int(g.pipeWidth), g.height)
-		g.pipePairs = append(g.pipePairs, pipes)
+// SetPhysics changes physics of following runs. It's ignored while a replay is played back,
+// which has physics of its own.
+func (g *Game) SetPhysics(physics world.Physics) {
+	if g.playback != nil {
+		return
 	}
+	g.worldCfg.Physics = physics
 }
 
-func (g *Game) moveBird() {
-	if g.bird.Y+g.bird.Height <= g.height {
-		g.bird.Move()
-	}
+// SetBestScore sets best score of previous games
+func (g *Game) SetBestScore(bestScore int) {
+	g.bestScore = bestScore
 }
-func (g *Game) moveScene() {
-	for _, pp := range g.pipePairs {
-		pp.Move(-2)
-	}
+
+// SetReplay makes every following run play back rep instead of reading player's input
+func (g *Game) SetReplay(rep *replay.Replay) {
+	g.playback = rep
+	g.worldCfg = rep.World
+	g.SetSeed(rep.Seed)
 }
 
-func (g *Game) updateScore() {
-	for _, pp := range g.pipePairs {
-		if !pp.Counted && pp.X+pp.Width < g.bird.X {
-			pp.Counted = true
-			g.score++
-			g.bestScore = int(math.Max(float64(g.score), float64(g.bestScore)))
-		}
+func (g *Game) reset() {
+	if !g.fixedSeed {
+		g.seed = time.Now().UnixNano() % 1e9
 	}
+	g.world = world.New(g.worldCfg, rand.New(rand.NewSource(g.seed)))
+	g.recorder = replay.NewRecorder(g.seed, g.worldCfg)
+	g.player = nil
+	if g.playback != nil {
+		g.player = replay.NewPlayer(g.playback)
+	}
+	g.flap = false
+	g.lag = 0
+	g.alpha = 0
 }
 
-func (g *Game) deleteHiddenPipes() {
-	pipes := []*gameobj.PipePair{}
-	for _, pp := range g.pipePairs {
-		if pp.X+pp.Width >= 0 {
-			pipes = append(pipes, pp)
-		}
+func (g *Game) step() {
+	in := world.Input{Flap: g.flap}
+	if g.player != nil {
+		in = g.player.Next()
 	}
-	g.pipePairs = pipes
+	g.flap = false
+
+	g.recorder.Record(in)
+	g.playSounds(g.world.Step(in))
 }
 
-func (g *Game) paint(renderer *sdl.Renderer) error {
-	renderer.Clear()
+func (g *Game) playSounds(events world.Events) {
+	if events.Has(world.Flapped) {
+		g.audio.Play(audio.Flap)
+	}
+	if events.Has(world.Scored) {
+		g.audio.Play(audio.Score)
+	}
+	if events.Has(world.Hit) {
+		g.audio.Play(audio.Hit)
+	}
+	if events.Has(world.Fell) {
+		g.audio.Play(audio.Fall)
+	}
+}
 
-	if err := renderer.Copy(g.bg, nil, nil); err != nil {
+func (g *Game) draw(renderer render.Renderer, alpha float64) error {
+	if err := renderer.DrawTexture(g.bg.Texture, nil, nil); err != nil {
 		return fmt.Errorf("could not copy background: %v", err)
 	}
 
 	drawOutline := false
-	if err := g.bird.Paint(renderer, drawOutline); err != nil {
+	if err := g.bird.Paint(renderer, g.world.Bird, alpha, drawOutline); err != nil {
 		return fmt.Errorf("could paint bird: %v", err)
 	}
 
-	for _, p := range g.pipePairs {
-		if err := p.Paint(renderer); err != nil {
+	for _, pp := range g.world.PipePairs {
+		if err := g.pipePair.Paint(renderer, pp, alpha); err != nil {
 			return fmt.Errorf("could paint pipe: %v", err)
 		}
 	}
@@ -247,31 +285,14 @@ func (g *Game) paint(renderer *sdl.Renderer) error {
 		return fmt.Errorf("could not paint score: %v", err)
 	}
 
-	renderer.Present()
 	return nil
 }
 
-func (g *Game) paintScore(renderer *sdl.Renderer) error {
-	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
-	text, err := g.scoreFont.RenderUTF8_Solid(strconv.Itoa(g.score), white)
-	if err != nil {
-		return fmt.Errorf("could not render score: %v", err)
+func (g *Game) paintScore(renderer render.Renderer) error {
+	style := text.Style{
+		Color:        color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+		Outline:      2,
+		OutlineColor: color.NRGBA{R: 0, G: 0, B: 0, A: 255},
 	}
-	defer text.Free()
-
-	t, err := renderer.CreateTextureFromSurface(text)
-	if err != nil {
-		return fmt.Errorf("cound not create texture: %v", err)
-	}
-	defer t.Destroy()
-
-	var clipRect sdl.Rect
-	text.GetClipRect(&clipRect)
-	rect := &sdl.Rect{X: int32(g.width)/2 - clipRect.W/2, Y: 60, W: clipRect.W, H: clipRect.H}
-
-	if err := renderer.Copy(t, nil, rect); err != nil {
-		return fmt.Errorf("cound not copy texture: %v", err)
-	}
-
-	return nil
+	return g.text.Draw(renderer, g.scoreFont, strconv.Itoa(g.world.Score), style, g.width/2, 60, text.Center)
 }
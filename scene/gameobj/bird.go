@@ -2,110 +2,110 @@ package gameobj
 
 import (
 	"fmt"
-
-	"github.com/veandco/go-sdl2/img"
-	"github.com/veandco/go-sdl2/sdl"
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/spoof/go-flappybird/animation"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/world"
 )
 
+// Clips of the bird animation
 const (
-	gravity = 0.1
+	flyClip  = "fly"
+	fallClip = "fall"
 )
 
-// Bird is a main character of this game
+// Bird paints the bird of the world
 type Bird struct {
-	time     int
-	textures []*sdl.Texture
-
-	X         int
-	Y         int
-	Width     int
-	Height    int
-	speedY    float32
-	angle     float64
-	isJumping bool
-
-	startX int
-	startY int
+	textures  []*res.Texture
+	sprites   map[string]sprite
+	animation *animation.Player
+
+	Width  int
+	Height int
 }
 
-// NewBird creates new bird object
-func NewBird(r *sdl.Renderer, x, y int) (*Bird, error) {
-	var textures []*sdl.Texture
-	for i := 1; i <= 4; i++ {
-		path := fmt.Sprintf("res/imgs/bird_frame_%d.png", i)
-		texture, err := img.LoadTexture(r, path)
+// sprite is a part of a texture. Nil rect means the whole texture.
+type sprite struct {
+	texture *res.Texture
+	rect    *image.Rectangle
+}
+
+// NewBird creates new bird object animated as the theme describes. Size of the bird is the size
+// of the first frame of its flying animation.
+func NewBird(rm *res.Manager) (*Bird, error) {
+	b := &Bird{sprites: make(map[string]sprite)}
+
+	theme := rm.Theme().Bird
+	var clips map[string]animation.Clip
+	if len(theme.Frames) > 0 {
+		clip := animation.Clip{Mode: animation.Loop}
+		for _, f := range theme.Frames {
+			texture, err := rm.Texture(f.Image)
+			if err != nil {
+				b.Destroy()
+				return nil, fmt.Errorf("cound not load bird texture: %v", err)
+			}
+			b.textures = append(b.textures, texture)
+			b.sprites[f.Image] = sprite{texture: texture}
+			clip.Frames = append(clip.Frames, animation.Frame{Name: f.Image, Duration: f.Duration})
+		}
+		clips = map[string]animation.Clip{flyClip: clip}
+	} else {
+		atlas, err := rm.Atlas(theme.Atlas)
 		if err != nil {
-			return nil, fmt.Errorf("cound not load bird texture: %v", err)
+			return nil, fmt.Errorf("cound not load bird atlas: %v", err)
 		}
-		textures = append(textures, texture)
+		b.textures = append(b.textures, atlas.Texture)
+		for name, rect := range atlas.Frames {
+			rect := rect
+			b.sprites[name] = sprite{texture: atlas.Texture, rect: &rect}
+		}
+		clips = atlas.Clips
 	}
 
-	_, _, birdWidth, birdHeight, err := textures[0].Query()
+	player, err := animation.NewPlayer(clips, flyClip)
 	if err != nil {
-		return nil, fmt.Errorf("could not get bird texure info: %v", err)
+		b.Destroy()
+		return nil, fmt.Errorf("invalid bird animation: %v", err)
 	}
-	width := int(birdWidth)
-	height := int(birdHeight)
-
-	bird := &Bird{textures: textures, startX: x, startY: y, Width: width, Height: height}
-	bird.ResetPosition()
+	b.animation = player
 
-	return bird, nil
-}
+	b.Width, b.Height = b.sprites[player.Frame()].size()
 
-// ResetPosition resets position of bird to the start one
-func (b *Bird) ResetPosition() {
-	b.X = b.startX - b.Width/2
-	b.Y = b.startY - b.Height/2
-	b.speedY = 0
-	b.angle = 0
+	return b, nil
 }
 
-// Jump makes bird jump
-func (b *Bird) Jump() {
-	if b.isJumping {
-		b.speedY--
-		return
+// Update advances animation of the bird by dt. Crashed bird plays its falling animation if the
+// theme has one.
+func (b *Bird) Update(dt time.Duration, crashed bool) {
+	clip := flyClip
+	if crashed && b.animation.Has(fallClip) {
+		clip = fallClip
 	}
 
-	b.isJumping = true
-	b.angle = 0
-	b.speedY = -4
-}
-
-// Fall makes bird fall
-func (b *Bird) Fall() {
-	b.speedY = 10
+	// both clips are known to exist
+	b.animation.Play(clip)
+	b.animation.Update(dt)
 }
 
-// Move moves bird
-func (b *Bird) Move() {
-	b.speedY += gravity
-	b.Y += int(b.speedY)
-
-	if b.isJumping && b.speedY >= 0 {
-		b.isJumping = false
-		b.speedY = 0
-	}
-}
-
-// Paint paints the bird.
-func (b *Bird) Paint(r *sdl.Renderer, drawOutline bool) error {
-	b.time++
-
-	rect := &sdl.Rect{X: int32(b.X), Y: int32(b.Y), W: int32(b.Width), H: int32(b.Height)}
+// Paint paints the bird interpolated by alpha between its previous and current state.
+func (b *Bird) Paint(r render.Renderer, bird *world.Bird, alpha float64, drawOutline bool) error {
+	y, angle := bird.Interpolate(alpha)
+	rect := &image.Rectangle{Min: image.Pt(int(bird.X), int(y))}
+	rect.Max = rect.Min.Add(image.Pt(bird.Width, bird.Height))
 	if drawOutline {
-		r.SetDrawColor(255, 0, 0, 0)
-		r.FillRect(rect)
-		r.DrawRect(rect)
+		if err := r.FillRect(rect, color.NRGBA{R: 255, A: 128}); err != nil {
+			return fmt.Errorf("could not paint bird outline: %v", err)
+		}
 	}
 
-	i := b.time / 8 % len(b.textures)
-	if b.speedY >= 5 && b.angle < 90 {
-		b.angle += 3.0
-	}
-	if err := r.CopyEx(b.textures[i], nil, rect, b.angle, nil, sdl.FLIP_NONE); err != nil {
-		return fmt.Errorf("could not copy background: %v", err)
+	s := b.sprites[b.animation.Frame()]
+	if err := r.DrawSprite(s.texture.Texture, s.rect, rect, angle, render.FlipNone); err != nil {
+		return fmt.Errorf("could not copy bird: %v", err)
 	}
 
 	return nil
@@ -114,6 +114,13 @@ func (b *Bird) Paint(r *sdl.Renderer, drawOutline bool) error {
 // Destroy frees all resources of Bird
 func (b *Bird) Destroy() {
 	for _, t := range b.textures {
-		t.Destroy()
+		t.Release()
+	}
+}
+
+func (s sprite) size() (w, h int) {
+	if s.rect != nil {
+		return s.rect.Dx(), s.rect.Dy()
 	}
+	return s.texture.Size()
 }
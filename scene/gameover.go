@@ -1,153 +1,288 @@
 package scene
 
 import (
+	"bytes"
 	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"path/filepath"
 	"strconv"
+	"time"
+	"unicode/utf8"
 
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/replay"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/text"
+	"github.com/spoof/go-flappybird/scene/ui"
 	"github.com/veandco/go-sdl2/sdl"
-	"github.com/veandco/go-sdl2/ttf"
 )
 
 // GameOver is game over scene
 type GameOver struct {
+	Base
+
 	width  int
 	height int
 
-	captionFont *ttf.Font
+	captionFont *res.Font
+	seedFont    *res.Font
+	screen      *ui.Screen
+	best        *ui.Label
+	seedLabel   *ui.Label
+	notice      *ui.Label
+	playAgain   *ui.Button
 
 	bestScore int
+	seed      int64
+	replay    *replay.Replay
+	replayDir string
+
+	enteringName bool
+	name         string
+	result       Event
+}
+
+var captionStyle = text.Style{
+	Color:       color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+	Shadow:      image.Pt(2, 2),
+	ShadowColor: color.NRGBA{R: 0, G: 0, B: 0, A: 160},
 }
 
-// NewGameOver creates new GameOver scene
-func NewGameOver(r *sdl.Renderer, width, height int) (*GameOver, error) {
-	captionFont, err := ttf.OpenFont("res/fonts/flappy.ttf", 42)
+const (
+	maxNameLength = 12
+	defaultName   = "Player"
+)
+
+// NewGameOver creates new GameOver scene which is painted over the finished game. Replays player
+// saves are written to replayDir.
+func NewGameOver(rm *res.Manager, width, height int, replayDir string) (*GameOver, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	captionFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, captionFont.Release)
+
+	seedFont, err := rm.Font(rm.Theme().Fonts.Title, 20)
 	if err != nil {
 		return nil, fmt.Errorf("cound not load font: %v", err)
 	}
+	acquired = append(acquired, seedFont.Release)
 
-	return &GameOver{
+	gos := &GameOver{
 		width:       width,
 		height:      height,
 		captionFont: captionFont,
-	}, nil
-}
+		seedFont:    seedFont,
+		screen:      ui.NewScreen(width, height),
+		replayDir:   replayDir,
+	}
 
-// Run run the scene's loop
-func (gos *GameOver) Run(in <-chan sdl.Event, r *sdl.Renderer) <-chan Event {
-	out := make(chan Event, 1)
-	go func() {
-		defer close(out)
+	gos.best = &ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 300}, Font: captionFont, Style: captionStyle}
+	gos.seedLabel = &ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 380}, Font: seedFont, Style: captionStyle}
+	gos.notice = &ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 440, W: width, H: 40}, Font: seedFont}
+	gos.playAgain = &ui.Button{
+		Layout:  ui.Layout{Anchor: ui.Bottom, Y: -40},
+		Font:    seedFont,
+		Text:    "Play again",
+		OnClick: func() { gos.result = &StartGameEvent{} },
+	}
+	gos.screen.Add(
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 200}, Font: captionFont, Text: "Game Over", Style: captionStyle},
+		gos.best,
+		gos.seedLabel,
+		gos.notice,
+		&ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Bottom, Y: -80},
+			Font:    seedFont,
+			Text:    "Save replay",
+			OnClick: gos.saveReplay,
+		},
+		gos.playAgain,
+	)
 
-		if err := gos.paint(r); err != nil {
-			out <- &ErrorEvent{Err: err}
-			return
-		}
+	ok = true
+	return gos, nil
+}
 
-		for {
-			select {
-			case event, ok := <-in:
-				if !ok {
-					return
-				}
-				if gos.handleEvent(event) {
-					out <- &StartGameEvent{}
-					return
-				}
-			}
-		}
+// Enter implements Scene. It prepares the scene for the game set with SetBestScore, SetSeed
+// and SetReplay.
+func (gos *GameOver) Enter() {
+	gos.name = ""
+	gos.notice.Text = ""
+	gos.screen.Focus(gos.playAgain)
+	if gos.enteringName {
+		sdl.StartTextInput()
+		gos.updateNamePrompt()
+	}
+}
 
-	}()
+// Resume implements Scene. Player gets back from the leaderboard after the name is submitted
+// and can save the replay.
+func (gos *GameOver) Resume() {
+	gos.notice.Text = ""
+}
 
-	return out
+// Exit implements Scene. It stops typing of the name if player has left without submitting it.
+func (gos *GameOver) Exit() {
+	if gos.enteringName {
+		sdl.StopTextInput()
+		gos.enteringName = false
+	}
+}
+
+// Transparent implements Scene, the finished game is seen under the scene
+func (gos *GameOver) Transparent() bool {
+	return true
 }
 
 // SetBestScore sets new best score of game
 func (gos *GameOver) SetBestScore(bestScore int) {
 	gos.bestScore = bestScore
+	gos.best.Text = "Best Score: " + strconv.Itoa(bestScore)
 }
 
-// Destroy frees all resources used by GameOver scene
-func (gos *GameOver) Destroy() {
-	gos.captionFont.Close()
+// SetSeed sets seed the finished game was played with
+func (gos *GameOver) SetSeed(seed int64) {
+	gos.seed = seed
+	gos.seedLabel.Text = "Seed: " + strconv.FormatInt(seed, 10)
 }
 
-func (gos *GameOver) handleEvent(event sdl.Event) (playAgain bool) {
-	switch e := event.(type) {
-	case *sdl.MouseButtonEvent:
-		if e.Type == sdl.MOUSEBUTTONDOWN {
-			return true
-		}
-	}
+// SetReplay sets replay of the finished game which player can save
+func (gos *GameOver) SetReplay(rep *replay.Replay) {
+	gos.replay = rep
+}
+
+// SetNameEntry makes the scene ask player's name for the leaderboard before leaving
+func (gos *GameOver) SetNameEntry(enabled bool) {
+	gos.enteringName = enabled
+}
 
-	return false
+// Destroy frees all resources used by GameOver scene
+func (gos *GameOver) Destroy() {
+	gos.captionFont.Release()
+	gos.seedFont.Release()
+	gos.screen.Destroy()
 }
 
-func (gos *GameOver) paint(renderer *sdl.Renderer) error {
-	rect := &sdl.Rect{X: 0, Y: 0, W: int32(gos.width), H: int32(gos.height)}
+// HandleEvent implements Scene
+func (gos *GameOver) HandleEvent(event input.Event) Event {
+	if gos.enteringName {
+		return gos.handleNameEvent(event)
+	}
 
-	renderer.SetDrawColor(0, 0, 0, 128)
-	renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
-	renderer.FillRect(rect)
-	renderer.DrawRect(rect)
+	gos.result = nil
+	if gos.screen.HandleEvent(event) {
+		return gos.result
+	}
 
-	if err := gos.paintCaption(renderer); err != nil {
-		return fmt.Errorf("could not render caption: %v", err)
+	if event.Actions.Has(input.Confirm) {
+		return &StartGameEvent{}
 	}
 
-	if err := gos.paintBestScoreCaption(renderer); err != nil {
-		return fmt.Errorf("could not render best score caption: %v", err)
+	if event.Actions.Has(input.Back) {
+		return &ReplaceEvent{Scene: MenuScene, All: true, Back: true}
 	}
 
-	renderer.Present()
 	return nil
 }
 
-func (gos *GameOver) paintCaption(renderer *sdl.Renderer) error {
-	c := sdl.Color{R: 255, G: 255, B: 255, A: 255}
-	captionSurface, err := gos.captionFont.RenderUTF8_Solid("Game Over", c)
-	if err != nil {
-		return fmt.Errorf("could not render title: %v", err)
-	}
-	defer captionSurface.Free()
+// handleNameEvent handles events while player types a name. Keys are used for typing, so
+// keyboard bindings are ignored.
+func (gos *GameOver) handleNameEvent(event input.Event) Event {
+	switch e := event.SDL.(type) {
+	case *sdl.TextInputEvent:
+		text := e.Text[:]
+		if i := bytes.IndexByte(text, 0); i >= 0 {
+			text = text[:i]
+		}
+		if utf8.RuneCountInString(gos.name)+utf8.RuneCount(text) <= maxNameLength {
+			gos.name += string(text)
+		}
 
-	t, err := renderer.CreateTextureFromSurface(captionSurface)
-	if err != nil {
-		return fmt.Errorf("cound not create texture: %v", err)
+	case *sdl.KeyboardEvent:
+		if e.Type != sdl.KEYDOWN {
+			return nil
+		}
+		switch e.Keysym.Sym {
+		case sdl.K_BACKSPACE:
+			if gos.name != "" {
+				_, size := utf8.DecodeLastRuneInString(gos.name)
+				gos.name = gos.name[:len(gos.name)-size]
+			}
+		case sdl.K_RETURN, sdl.K_KP_ENTER:
+			return gos.submitName()
+		}
+
+	default:
+		if event.Actions.Has(input.Confirm) {
+			return gos.submitName()
+		}
+		return nil
 	}
-	defer t.Destroy()
 
-	var clipRect sdl.Rect
-	captionSurface.GetClipRect(&clipRect)
-	rect := &sdl.Rect{X: int32(gos.width)/2 - clipRect.W/2, Y: 200, W: clipRect.W, H: clipRect.H}
+	gos.updateNamePrompt()
+	return nil
+}
+
+func (gos *GameOver) updateNamePrompt() {
+	gos.notice.Text = "New high score! Your name: " + gos.name + "_"
+}
+
+func (gos *GameOver) submitName() Event {
+	sdl.StopTextInput()
+	gos.enteringName = false
 
-	if err := renderer.Copy(t, nil, rect); err != nil {
-		return fmt.Errorf("cound not copy texture: %v", err)
+	name := gos.name
+	if name == "" {
+		name = defaultName
 	}
 
-	return nil
+	return &SubmitScoreEvent{Name: name}
 }
 
-func (gos *GameOver) paintBestScoreCaption(renderer *sdl.Renderer) error {
-	c := sdl.Color{R: 255, G: 255, B: 255, A: 255}
-	text := "Best Score: " + strconv.Itoa(gos.bestScore)
-	captionSurface, err := gos.captionFont.RenderUTF8_Solid(text, c)
-	if err != nil {
-		return fmt.Errorf("could not render title: %v", err)
+// saveReplay saves replay of the game once, the button does nothing after that
+func (gos *GameOver) saveReplay() {
+	if gos.replay == nil {
+		return
 	}
-	defer captionSurface.Free()
 
-	t, err := renderer.CreateTextureFromSurface(captionSurface)
-	if err != nil {
-		return fmt.Errorf("cound not create texture: %v", err)
+	name := fmt.Sprintf("flappybird-%d-%s.replay", gos.seed, time.Now().Format("20060102-150405"))
+	if err := gos.replay.Save(filepath.Join(gos.replayDir, name)); err != nil {
+		log.Printf("could not save replay: %v", err)
+		gos.notice.Text = "Could not save replay"
+		return
 	}
-	defer t.Destroy()
+	gos.notice.Text = "Replay saved to " + name
+	gos.replay = nil
+}
 
-	var clipRect sdl.Rect
-	captionSurface.GetClipRect(&clipRect)
-	rect := &sdl.Rect{X: int32(gos.width)/2 - clipRect.W/2, Y: 300, W: clipRect.W, H: clipRect.H}
+// Render implements Scene
+func (gos *GameOver) Render(renderer render.Renderer) error {
+	rect := &image.Rectangle{Max: image.Pt(gos.width, gos.height)}
+	if err := renderer.FillRect(rect, color.NRGBA{A: 128}); err != nil {
+		return fmt.Errorf("could not shade game: %v", err)
+	}
+
+	if gos.notice.Text != "" {
+		bar := gos.notice.Layout
+		if err := renderer.FillRect(&image.Rectangle{Min: image.Pt(bar.X, bar.Y), Max: image.Pt(bar.X+bar.W, bar.Y+bar.H)}, color.NRGBA{A: 255}); err != nil {
+			return fmt.Errorf("could not paint notice bar: %v", err)
+		}
+	}
 
-	if err := renderer.Copy(t, nil, rect); err != nil {
-		return fmt.Errorf("cound not copy texture: %v", err)
+	if err := gos.screen.Paint(renderer); err != nil {
+		return fmt.Errorf("could not render game over: %v", err)
 	}
 
 	return nil
@@ -0,0 +1,147 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/save"
+	"github.com/spoof/go-flappybird/scene/ui"
+)
+
+// Leaderboard is the scene showing best runs
+type Leaderboard struct {
+	Base
+
+	width  int
+	height int
+
+	bg        *res.Texture
+	titleFont *res.Font
+	rowFont   *res.Font
+	screen    *ui.Screen
+	list      *ui.List
+
+	data      *save.Data
+	highlight int
+	done      bool
+}
+
+// NewLeaderboard creates new Leaderboard scene showing runs of data
+func NewLeaderboard(rm *res.Manager, width, height int, data *save.Data) (*Leaderboard, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	bg, err := rm.Texture(rm.Theme().Background)
+	if err != nil {
+		return nil, fmt.Errorf("could not load background image: %v", err)
+	}
+	acquired = append(acquired, bg.Release)
+
+	titleFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, titleFont.Release)
+
+	rowFont, err := rm.Font(rm.Theme().Fonts.Text, 20)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, rowFont.Release)
+
+	l := &Leaderboard{
+		width:     width,
+		height:    height,
+		bg:        bg,
+		titleFont: titleFont,
+		rowFont:   rowFont,
+		screen:    ui.NewScreen(width, height),
+		data:      data,
+		highlight: -1,
+		list: &ui.List{
+			Layout:    ui.Layout{Anchor: ui.Top, Y: 130},
+			Font:      rowFont,
+			RowHeight: 38,
+			Selected:  -1,
+			Empty:     "No runs yet",
+		},
+	}
+
+	l.screen.Add(
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 40}, Font: titleFont, Text: "Leaderboard"},
+		l.list,
+		&ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Bottom, Y: -40},
+			Font:    rowFont,
+			Text:    "Back",
+			OnClick: func() { l.done = true },
+		},
+	)
+
+	ok = true
+	return l, nil
+}
+
+// SetHighlight highlights entry at position i the next time the scene is shown
+func (l *Leaderboard) SetHighlight(i int) {
+	l.highlight = i
+}
+
+// setEntries shows the current entries of the leaderboard
+func (l *Leaderboard) setEntries() {
+	entries := l.data.Leaderboard
+	l.list.Items = make([]string, len(entries))
+	for i, e := range entries {
+		l.list.Items[i] = fmt.Sprintf("%d.\t%s\t%d\tseed %d\t%s",
+			i+1, e.Name, e.Score, e.Seed, e.Date.Format("2006-01-02"))
+	}
+	l.list.Selected = l.highlight
+	l.highlight = -1
+}
+
+// Enter implements Scene. It shows the current entries of the leaderboard.
+func (l *Leaderboard) Enter() {
+	l.setEntries()
+}
+
+// HandleEvent implements Scene
+func (l *Leaderboard) HandleEvent(e input.Event) Event {
+	if e.Actions.Has(input.Back) {
+		return &PopEvent{}
+	}
+
+	l.done = false
+	l.screen.HandleEvent(e)
+	if l.done {
+		return &PopEvent{}
+	}
+	return nil
+}
+
+// Destroy frees all resources
+func (l *Leaderboard) Destroy() {
+	l.bg.Release()
+	l.titleFont.Release()
+	l.rowFont.Release()
+	l.screen.Destroy()
+}
+
+// Render implements Scene
+func (l *Leaderboard) Render(r render.Renderer) error {
+	if err := r.DrawTexture(l.bg.Texture, nil, nil); err != nil {
+		return fmt.Errorf("could not copy background: %v", err)
+	}
+
+	if err := l.screen.Paint(r); err != nil {
+		return fmt.Errorf("could not paint leaderboard: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package scene
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/text"
+	"github.com/spoof/go-flappybird/scene/ui"
+)
+
+// Menu is the main menu of the game
+type Menu struct {
+	Base
+
+	bg        *res.Texture
+	titleFont *res.Font
+	itemFont  *res.Font
+	screen    *ui.Screen
+	play      *ui.Button
+
+	result Event
+}
+
+// NewMenu creates new Menu scene
+func NewMenu(rm *res.Manager, width, height int) (*Menu, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	bg, err := rm.Texture(rm.Theme().Background)
+	if err != nil {
+		return nil, fmt.Errorf("could not load background image: %v", err)
+	}
+	acquired = append(acquired, bg.Release)
+
+	titleFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, titleFont.Release)
+
+	itemFont, err := rm.Font(rm.Theme().Fonts.Title, 26)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, itemFont.Release)
+
+	m := &Menu{
+		bg:        bg,
+		titleFont: titleFont,
+		itemFont:  itemFont,
+		screen:    ui.NewScreen(width, height),
+	}
+
+	m.screen.Add(&ui.Label{
+		Layout: ui.Layout{Anchor: ui.Top, Y: 60},
+		Font:   titleFont,
+		Text:   "Flappy Bird",
+		Style: text.Style{
+			Color:       color.NRGBA{R: 255, G: 100, B: 0, A: 255},
+			Shadow:      image.Pt(3, 3),
+			ShadowColor: color.NRGBA{R: 0, G: 0, B: 0, A: 128},
+		},
+	})
+
+	items := []struct {
+		text  string
+		event Event
+	}{
+		{"Play", &StartGameEvent{Mode: Classic}},
+		{"Modes", &PushEvent{Scene: ModesScene}},
+		{"Leaderboard", &PushEvent{Scene: LeaderboardScene}},
+		{"Settings", &PushEvent{Scene: SettingsScene}},
+		{"Quit", &QuitEvent{}},
+	}
+	for i, item := range items {
+		event := item.event
+		b := &ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Top, Y: 200 + i*60},
+			Font:    itemFont,
+			Text:    item.text,
+			OnClick: func() { m.result = event },
+		}
+		if i == 0 {
+			m.play = b
+		}
+		m.screen.Add(b)
+	}
+
+	ok = true
+	return m, nil
+}
+
+// Enter implements Scene
+func (m *Menu) Enter() {
+	m.screen.Focus(m.play)
+}
+
+// HandleEvent implements Scene
+func (m *Menu) HandleEvent(e input.Event) Event {
+	if e.Actions.Has(input.Back) {
+		return &ReplaceEvent{Scene: SplashScene, Back: true}
+	}
+
+	m.result = nil
+	m.screen.HandleEvent(e)
+	return m.result
+}
+
+// Destroy frees all resources
+func (m *Menu) Destroy() {
+	m.bg.Release()
+	m.titleFont.Release()
+	m.itemFont.Release()
+	m.screen.Destroy()
+}
+
+// Render implements Scene
+func (m *Menu) Render(r render.Renderer) error {
+	if err := r.DrawTexture(m.bg.Texture, nil, nil); err != nil {
+		return fmt.Errorf("could not copy background: %v", err)
+	}
+
+	if err := m.screen.Paint(r); err != nil {
+		return fmt.Errorf("could not paint menu: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package scene
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/ui"
+)
+
+// Mode is a way to play the game
+type Mode int
+
+// Modes of the game
+const (
+	// Classic generates new pipes for every run
+	Classic Mode = iota + 1
+	// Daily generates the same pipes for everyone during a day
+	Daily
+)
+
+// DailySeed returns seed of pipes for the day of t in UTC, so players in all time zones get the
+// same pipes
+func DailySeed(t time.Time) int64 {
+	y, m, d := t.UTC().Date()
+	return int64(y*10000 + int(m)*100 + d)
+}
+
+// Modes is the scene where player picks a mode of the game
+type Modes struct {
+	Base
+
+	bg        *res.Texture
+	titleFont *res.Font
+	itemFont  *res.Font
+	hintFont  *res.Font
+	screen    *ui.Screen
+	classic   *ui.Button
+
+	result Event
+}
+
+// NewModes creates new Modes scene
+func NewModes(rm *res.Manager, width, height int) (*Modes, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	bg, err := rm.Texture(rm.Theme().Background)
+	if err != nil {
+		return nil, fmt.Errorf("could not load background image: %v", err)
+	}
+	acquired = append(acquired, bg.Release)
+
+	titleFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, titleFont.Release)
+
+	itemFont, err := rm.Font(rm.Theme().Fonts.Title, 26)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, itemFont.Release)
+
+	hintFont, err := rm.Font(rm.Theme().Fonts.Text, 16)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, hintFont.Release)
+
+	m := &Modes{
+		bg:        bg,
+		titleFont: titleFont,
+		itemFont:  itemFont,
+		hintFont:  hintFont,
+		screen:    ui.NewScreen(width, height),
+	}
+
+	m.classic = &ui.Button{
+		Layout:  ui.Layout{Anchor: ui.Top, Y: 180},
+		Font:    itemFont,
+		Text:    "Classic",
+		OnClick: func() { m.result = &StartGameEvent{Mode: Classic} },
+	}
+	m.screen.Add(
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 40}, Font: titleFont, Text: "Modes"},
+		m.classic,
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 225}, Font: hintFont, Text: "New pipes every run"},
+		&ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Top, Y: 300},
+			Font:    itemFont,
+			Text:    "Daily challenge",
+			OnClick: func() { m.result = &StartGameEvent{Mode: Daily} },
+		},
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 345}, Font: hintFont, Text: "The same pipes for everyone until midnight UTC"},
+		&ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Bottom, Y: -40},
+			Font:    itemFont,
+			Text:    "Back",
+			OnClick: func() { m.result = &PopEvent{} },
+		},
+	)
+
+	ok = true
+	return m, nil
+}
+
+// Enter implements Scene
+func (m *Modes) Enter() {
+	m.screen.Focus(m.classic)
+}
+
+// HandleEvent implements Scene
+func (m *Modes) HandleEvent(e input.Event) Event {
+	if e.Actions.Has(input.Back) {
+		return &PopEvent{}
+	}
+
+	m.result = nil
+	m.screen.HandleEvent(e)
+	return m.result
+}
+
+// Destroy frees all resources
+func (m *Modes) Destroy() {
+	m.bg.Release()
+	m.titleFont.Release()
+	m.itemFont.Release()
+	m.hintFont.Release()
+	m.screen.Destroy()
+}
+
+// Render implements Scene
+func (m *Modes) Render(r render.Renderer) error {
+	if err := r.DrawTexture(m.bg.Texture, nil, nil); err != nil {
+		return fmt.Errorf("could not copy background: %v", err)
+	}
+
+	if err := m.screen.Paint(r); err != nil {
+		return fmt.Errorf("could not paint modes: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package scene
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/ui"
+)
+
+// Pause is the scene shown over the paused game
+type Pause struct {
+	Base
+
+	width  int
+	height int
+
+	captionFont *res.Font
+	itemFont    *res.Font
+	screen      *ui.Screen
+	resume      *ui.Button
+
+	result Event
+}
+
+// NewPause creates new Pause scene which is painted over the paused game
+func NewPause(rm *res.Manager, width, height int) (*Pause, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	captionFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, captionFont.Release)
+
+	itemFont, err := rm.Font(rm.Theme().Fonts.Title, 26)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, itemFont.Release)
+
+	p := &Pause{
+		width:       width,
+		height:      height,
+		captionFont: captionFont,
+		itemFont:    itemFont,
+		screen:      ui.NewScreen(width, height),
+	}
+
+	p.resume = &ui.Button{
+		Layout:  ui.Layout{Anchor: ui.Top, Y: 280},
+		Font:    itemFont,
+		Text:    "Resume",
+		OnClick: func() { p.result = &PopEvent{} },
+	}
+	p.screen.Add(
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 150}, Font: captionFont, Text: "Paused"},
+		p.resume,
+		&ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Top, Y: 340},
+			Font:    itemFont,
+			Text:    "Restart",
+			OnClick: func() { p.result = &StartGameEvent{} },
+		},
+		&ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Top, Y: 400},
+			Font:    itemFont,
+			Text:    "Quit to menu",
+			OnClick: func() { p.result = &ReplaceEvent{Scene: MenuScene, All: true, Back: true} },
+		},
+	)
+
+	ok = true
+	return p, nil
+}
+
+// Enter implements Scene
+func (p *Pause) Enter() {
+	p.screen.Focus(p.resume)
+}
+
+// Transparent implements Scene, the paused game is seen under the menu
+func (p *Pause) Transparent() bool {
+	return true
+}
+
+// Destroy frees all resources
+func (p *Pause) Destroy() {
+	p.captionFont.Release()
+	p.itemFont.Release()
+	p.screen.Destroy()
+}
+
+// HandleEvent implements Scene
+func (p *Pause) HandleEvent(e input.Event) Event {
+	if e.Actions.Has(input.Pause | input.Back) {
+		return &PopEvent{}
+	}
+
+	p.result = nil
+	p.screen.HandleEvent(e)
+	return p.result
+}
+
+// Render implements Scene
+func (p *Pause) Render(r render.Renderer) error {
+	rect := &image.Rectangle{Max: image.Pt(p.width, p.height)}
+	if err := r.FillRect(rect, color.NRGBA{A: 128}); err != nil {
+		return fmt.Errorf("could not shade game: %v", err)
+	}
+
+	if err := p.screen.Paint(r); err != nil {
+		return fmt.Errorf("could not paint menu: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package scene
+
+import (
+	"time"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+)
+
+// Scene is a screen of the game, e.g. menu or the game itself. Scenes don't run on their own:
+// the scene manager calls lifecycle methods as scenes are put on and taken off its stack, and
+// HandleEvent, Update and Render of the top scene every frame.
+type Scene interface {
+	// Enter is called when the scene is put on the stack
+	Enter()
+	// Exit is called when the scene is taken off the stack
+	Exit()
+	// Pause is called when another scene is put above the scene
+	Pause()
+	// Resume is called when the scene is on top of the stack again
+	Resume()
+
+	// HandleEvent handles input of player. It returns event for the manager or nil.
+	HandleEvent(e input.Event) Event
+	// Update advances the scene by dt. It returns event for the manager or nil.
+	Update(dt time.Duration) Event
+	// Render paints the scene without presenting it
+	Render(r render.Renderer) error
+
+	// Transparent reports whether the scene is painted over the scene below it, e.g. pause
+	// menu over the game. The scene below is rendered, but it's paused.
+	Transparent() bool
+
+	// Capturing reports whether the scene takes raw keys and buttons, e.g. to bind them to
+	// actions. The manager doesn't handle global actions like Quit and Mute meanwhile.
+	Capturing() bool
+
+	// Destroy frees all resources
+	Destroy()
+}
+
+// Base implements lifecycle methods of Scene doing nothing. Scenes embed it and implement the
+// methods they need.
+type Base struct{}
+
+// Enter implements Scene
+func (Base) Enter() {}
+
+// Exit implements Scene
+func (Base) Exit() {}
+
+// Pause implements Scene
+func (Base) Pause() {}
+
+// Resume implements Scene
+func (Base) Resume() {}
+
+// Update implements Scene
+func (Base) Update(dt time.Duration) Event { return nil }
+
+// Transparent implements Scene
+func (Base) Transparent() bool { return false }
+
+// Capturing implements Scene
+func (Base) Capturing() bool { return false }
+
+// release calls release functions of resources acquired by a constructor which has failed, in
+// reverse order
+func release(acquired []func()) {
+	for i := len(acquired) - 1; i >= 0; i-- {
+		acquired[i]()
+	}
+}
@@ -0,0 +1,291 @@
+package scene
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spoof/go-flappybird/audio"
+	"github.com/spoof/go-flappybird/config"
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/ui"
+)
+
+// Settings is the scene where player changes options of the game. Volumes are applied at once,
+// so player hears them, the rest is applied when player leaves the scene.
+type Settings struct {
+	Base
+
+	bg        *res.Texture
+	titleFont *res.Font
+	itemFont  *res.Font
+	hintFont  *res.Font
+	screen    *ui.Screen
+	audio     *audio.Audio
+
+	cfg    config.Config
+	themes []string
+
+	first      ui.Interactive
+	mute       *ui.Toggle
+	difficulty *ui.Choice
+	note       *ui.Label
+	result     Event
+}
+
+// NewSettings creates new Settings scene which changes cfg. Player picks theme out of themes,
+// volumes are previewed with a.
+func NewSettings(rm *res.Manager, width, height int, cfg config.Config, themes []string, a *audio.Audio) (*Settings, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	bg, err := rm.Texture(rm.Theme().Background)
+	if err != nil {
+		return nil, fmt.Errorf("could not load background image: %v", err)
+	}
+	acquired = append(acquired, bg.Release)
+
+	titleFont, err := rm.Font(rm.Theme().Fonts.Title, 42)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, titleFont.Release)
+
+	itemFont, err := rm.Font(rm.Theme().Fonts.Text, 20)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, itemFont.Release)
+
+	hintFont, err := rm.Font(rm.Theme().Fonts.Text, 14)
+	if err != nil {
+		return nil, fmt.Errorf("cound not load font: %v", err)
+	}
+	acquired = append(acquired, hintFont.Release)
+
+	s := &Settings{
+		bg:        bg,
+		titleFont: titleFont,
+		itemFont:  itemFont,
+		hintFont:  hintFont,
+		screen:    ui.NewScreen(width, height),
+		audio:     a,
+		cfg:       cfg,
+		themes:    append([]string{""}, themes...),
+	}
+	s.build()
+
+	ok = true
+	return s, nil
+}
+
+// build adds widgets showing current settings to the screen
+func (s *Settings) build() {
+	row := func(i int) ui.Layout {
+		return ui.Layout{Anchor: ui.Top, Y: 110 + i*46}
+	}
+	volume := func(i int, name string, v *float64) *ui.Slider {
+		return &ui.Slider{
+			Layout: row(i),
+			Font:   s.itemFont,
+			Text:   name,
+			Value:  *v,
+			Min:    0,
+			Max:    1,
+			Step:   0.1,
+			OnChange: func(value float64) {
+				*v = value
+				s.audio.SetVolume(s.cfg.Audio.Master, s.cfg.Audio.Music, s.cfg.Audio.SFX)
+			},
+		}
+	}
+
+	master := volume(0, "Volume", &s.cfg.Audio.Master)
+	s.first = master
+
+	s.mute = &ui.Toggle{
+		Layout: row(3),
+		Font:   s.itemFont,
+		Text:   "Mute",
+		On:     s.cfg.Audio.Muted,
+		OnChange: func(on bool) {
+			s.cfg.Audio.Muted = on
+			s.audio.SetMuted(on)
+		},
+	}
+
+	window := &ui.Choice{
+		Layout:   row(4),
+		Font:     s.itemFont,
+		Text:     "Window",
+		Options:  []string{"Windowed", "Fullscreen"},
+		OnChange: func(i int) { s.cfg.Fullscreen = i == 1 },
+	}
+	if s.cfg.Fullscreen {
+		window.Selected = 1
+	}
+
+	theme := &ui.Choice{
+		Layout: row(5),
+		Font:   s.itemFont,
+		Text:   "Theme",
+		OnChange: func(i int) {
+			s.cfg.Theme = s.themes[i]
+			s.note.Text = "The theme is applied after restart"
+		},
+	}
+	if s.cfg.Theme != "" && indexOf(s.themes, s.cfg.Theme) < 0 {
+		s.themes = append(s.themes, s.cfg.Theme)
+	}
+	for _, path := range s.themes {
+		theme.Options = append(theme.Options, themeName(path))
+	}
+	theme.Selected = indexOf(s.themes, s.cfg.Theme)
+
+	s.difficulty = &ui.Choice{
+		Layout:   row(6),
+		Font:     s.itemFont,
+		Text:     "Difficulty",
+		OnChange: func(i int) { s.setDifficulty(config.Difficulties[i]) },
+		Selected: indexOf(config.Difficulties, s.cfg.Difficulty),
+	}
+	for _, d := range config.Difficulties {
+		s.difficulty.Options = append(s.difficulty.Options, difficultyName(d))
+	}
+
+	s.note = &ui.Label{Layout: ui.Layout{Anchor: ui.Bottom, Y: -90}, Font: s.hintFont}
+
+	s.screen.Add(
+		&ui.Label{Layout: ui.Layout{Anchor: ui.Top, Y: 30}, Font: s.titleFont, Text: "Settings"},
+		master,
+		volume(1, "Music", &s.cfg.Audio.Music),
+		volume(2, "Sound effects", &s.cfg.Audio.SFX),
+		s.mute,
+		window,
+		theme,
+		s.difficulty,
+		&ui.Button{
+			Layout:  row(7),
+			Font:    s.itemFont,
+			Text:    "Controls",
+			OnClick: func() { s.result = &PushEvent{Scene: ControlsScene} },
+		},
+		&ui.Button{
+			Layout:  ui.Layout{Anchor: ui.Bottom, Y: -40},
+			Font:    s.itemFont,
+			Text:    "Back",
+			OnClick: func() { s.result = s.apply() },
+		},
+		s.note,
+	)
+}
+
+// setDifficulty applies preset of the difficulty unless the game isn't playable with it, e.g.
+// gaps between pipes don't fit the window. A rejected preset is explained with the note.
+func (s *Settings) setDifficulty(difficulty string) {
+	cfg := s.cfg
+	if err := cfg.SetDifficulty(difficulty); err != nil {
+		s.note.Text = err.Error()
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		s.note.Text = fmt.Sprintf("%s doesn't fit the window: %v", difficultyName(difficulty), err)
+		return
+	}
+
+	s.cfg = cfg
+	s.note.Text = ""
+}
+
+// Enter implements Scene. Settings player hasn't applied yet are kept while player changes
+// controls.
+func (s *Settings) Enter() {
+	s.screen.Focus(s.first)
+	s.syncMute()
+	// a rejected difficulty could have been left selected
+	s.difficulty.Selected = indexOf(config.Difficulties, s.cfg.Difficulty)
+}
+
+// Resume implements Scene
+func (s *Settings) Resume() {
+	s.syncMute()
+}
+
+// syncMute shows whether sounds are muted, they could have been muted with a key
+func (s *Settings) syncMute() {
+	s.cfg.Audio.Muted = s.audio.Muted()
+	s.mute.On = s.cfg.Audio.Muted
+}
+
+// HandleEvent implements Scene
+func (s *Settings) HandleEvent(e input.Event) Event {
+	if e.Actions.Has(input.Back) {
+		return s.apply()
+	}
+
+	s.result = nil
+	s.screen.HandleEvent(e)
+	return s.result
+}
+
+// Destroy frees all resources
+func (s *Settings) Destroy() {
+	s.bg.Release()
+	s.titleFont.Release()
+	s.itemFont.Release()
+	s.hintFont.Release()
+	s.screen.Destroy()
+}
+
+func (s *Settings) apply() Event {
+	return &ApplySettingsEvent{Config: s.cfg}
+}
+
+// Render implements Scene
+func (s *Settings) Render(r render.Renderer) error {
+	if err := r.DrawTexture(s.bg.Texture, nil, nil); err != nil {
+		return fmt.Errorf("could not copy background: %v", err)
+	}
+
+	if err := s.screen.Paint(r); err != nil {
+		return fmt.Errorf("could not paint settings: %v", err)
+	}
+
+	return nil
+}
+
+// themeName returns name of the theme at path shown to player
+func themeName(path string) string {
+	if path == "" {
+		return "Classic"
+	}
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// difficultyName returns name of the difficulty shown to player, e.g. "Easy"
+func difficultyName(difficulty string) string {
+	if difficulty == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(difficulty)
+	return string(unicode.ToUpper(r)) + difficulty[size:]
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
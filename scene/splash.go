@@ -2,158 +2,109 @@ package scene
 
 import (
 	"fmt"
-
-	"github.com/veandco/go-sdl2/img"
-	"github.com/veandco/go-sdl2/sdl"
-	"github.com/veandco/go-sdl2/ttf"
+	"image"
+	"image/color"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/text"
+	"github.com/spoof/go-flappybird/scene/ui"
 )
 
 // Splash is the first game scene
 type Splash struct {
-	bg         *sdl.Texture
-	logoFont   *ttf.Font
-	buttonFont *ttf.Font
+	Base
 
-	width  int
-	height int
+	bg         *res.Texture
+	logoFont   *res.Font
+	buttonFont *res.Font
+	screen     *ui.Screen
 }
 
 // NewSplash creates new TitleScreen
-func NewSplash(r *sdl.Renderer, width, height int) (*Splash, error) {
-	bg, err := img.LoadTexture(r, "res/imgs/background.png")
+func NewSplash(rm *res.Manager, width, height int) (*Splash, error) {
+	ok := false
+	var acquired []func()
+	defer func() {
+		if !ok {
+			release(acquired)
+		}
+	}()
+
+	bg, err := rm.Texture(rm.Theme().Background)
 	if err != nil {
 		return nil, fmt.Errorf("could not load background image: %v", err)
 	}
+	acquired = append(acquired, bg.Release)
 
-	logoFont, err := ttf.OpenFont("res/fonts/flappy.ttf", 26)
+	logoFont, err := rm.Font(rm.Theme().Fonts.Title, 26)
 	if err != nil {
 		return nil, fmt.Errorf("cound not load font: %v", err)
 	}
+	acquired = append(acquired, logoFont.Release)
 
-	buttonFont, err := ttf.OpenFont("res/fonts/VanillaExtractRegular.ttf", 16)
+	buttonFont, err := rm.Font(rm.Theme().Fonts.Text, 16)
 	if err != nil {
 		return nil, fmt.Errorf("cound not load font: %v", err)
 	}
-
+	acquired = append(acquired, buttonFont.Release)
+
+	screen := ui.NewScreen(width, height)
+	screen.Add(
+		&ui.Label{
+			Layout: ui.Layout{Anchor: ui.Top, Y: 40, W: width - 100, H: height / 2},
+			Font:   logoFont,
+			Text:   "Flappy Bird",
+			Style: text.Style{
+				Color:       color.NRGBA{R: 255, G: 100, B: 0, A: 255},
+				Shadow:      image.Pt(3, 3),
+				ShadowColor: color.NRGBA{R: 0, G: 0, B: 0, A: 128},
+			},
+			Stretch: true,
+		},
+		&ui.Label{
+			Layout:  ui.Layout{Anchor: ui.Bottom, Y: -120, W: width - 200, H: 80},
+			Font:    buttonFont,
+			Text:    "Press any key to start",
+			Style:   text.Style{Color: color.NRGBA{R: 150, G: 155, B: 45, A: 255}},
+			Stretch: true,
+		},
+	)
+
+	ok = true
 	return &Splash{
 		bg:         bg,
 		logoFont:   logoFont,
 		buttonFont: buttonFont,
-		width:      width,
-		height:     height,
+		screen:     screen,
 	}, nil
 }
 
-// Run runs the scene loop by listening events from in channel, renders all staff to r. Returns
-// channel to listen for events from the scene.
-func (s *Splash) Run(in <-chan sdl.Event, r *sdl.Renderer) <-chan Event {
-	out := make(chan Event, 1)
-
-	go func() {
-		defer close(out)
-
-		if err := s.paint(r); err != nil {
-			out <- &ErrorEvent{Err: err}
-			return
-		}
-
-		for {
-			select {
-			case e, ok := <-in:
-				if !ok {
-					return
-				}
-
-				switch event := e.(type) {
-				case *sdl.MouseButtonEvent:
-					if event.Type == sdl.MOUSEBUTTONDOWN {
-						out <- &StartGameEvent{}
-						return
-					}
-				}
-			}
-		}
-	}()
-
-	return out
+// HandleEvent implements Scene. Any key, button or touch starts the game.
+func (s *Splash) HandleEvent(e input.Event) Event {
+	if e.Pressed() {
+		return &ReplaceEvent{Scene: MenuScene}
+	}
+	return nil
 }
 
 // Destroy frees all resources
 func (s *Splash) Destroy() {
-	s.bg.Destroy()
-	s.logoFont.Close()
-	s.buttonFont.Close()
+	s.bg.Release()
+	s.logoFont.Release()
+	s.buttonFont.Release()
+	s.screen.Destroy()
 }
 
-func (s *Splash) paint(r *sdl.Renderer) error {
-	r.Clear()
-
-	if err := s.paintBg(r); err != nil {
+// Render implements Scene
+func (s *Splash) Render(r render.Renderer) error {
+	if err := r.DrawTexture(s.bg.Texture, nil, nil); err != nil {
 		return fmt.Errorf("could not paint background: %v", err)
 	}
 
-	if err := s.paintLogo(r); err != nil {
-		return fmt.Errorf("could not paint logo: %v", err)
-	}
-	if err := s.paintButton(r); err != nil {
-		return fmt.Errorf("could not paint logo: %v", err)
-	}
-
-	r.Present()
-	return nil
-}
-
-func (s *Splash) paintBg(r *sdl.Renderer) error {
-	if err := r.Copy(s.bg, nil, nil); err != nil {
-		return fmt.Errorf("could not copy background: %v", err)
-	}
-
-	return nil
-}
-
-func (s *Splash) paintLogo(r *sdl.Renderer) error {
-	c := sdl.Color{R: 255, G: 100, B: 0, A: 255}
-	logoSurface, err := s.logoFont.RenderUTF8_Solid("Flappy Bird", c)
-	if err != nil {
-		return fmt.Errorf("could not render title: %v", err)
-	}
-	defer logoSurface.Free()
-
-	t, err := r.CreateTextureFromSurface(logoSurface)
-	if err != nil {
-		return fmt.Errorf("cound not create texture: %v", err)
-	}
-	defer t.Destroy()
-
-	var clipRect sdl.Rect
-	logoSurface.GetClipRect(&clipRect)
-	rect := &sdl.Rect{X: 100 / 2, Y: 40, W: int32(s.width - 100), H: int32(s.height / 2)}
-	if err := r.Copy(t, nil, rect); err != nil {
-		return fmt.Errorf("cound not copy texture: %v", err)
-	}
-
-	return nil
-}
-
-func (s *Splash) paintButton(r *sdl.Renderer) error {
-	c := sdl.Color{R: 150, G: 155, B: 45, A: 0}
-	buttonSurface, err := s.buttonFont.RenderUTF8_Solid("Press any key to start", c)
-	if err != nil {
-		return fmt.Errorf("could not render title: %v", err)
-	}
-	defer buttonSurface.Free()
-
-	t, err := r.CreateTextureFromSurface(buttonSurface)
-	if err != nil {
-		return fmt.Errorf("cound not create texture: %v", err)
-	}
-	defer t.Destroy()
-
-	var clipRect sdl.Rect
-	buttonSurface.GetClipRect(&clipRect)
-	rect := &sdl.Rect{X: 200 / 2, Y: 400, W: int32(s.width - 200), H: int32(80)}
-	if err := r.Copy(t, nil, rect); err != nil {
-		return fmt.Errorf("cound not copy texture: %v", err)
+	if err := s.screen.Paint(r); err != nil {
+		return fmt.Errorf("could not paint splash: %v", err)
 	}
 
 	return nil
@@ -0,0 +1,206 @@
+// Package text paints text with TTF fonts. Rendered strings are cached as textures, so painting
+// the same text every frame is as cheap as painting an image.
+package text
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+)
+
+// Align defines which point of the text is placed at the given position. Horizontal and vertical
+// alignments are combined with |, e.g. Center|Middle. Left|Top is the default.
+type Align int
+
+// Alignments
+const (
+	Left   Align = 0
+	Center Align = 1
+	Right  Align = 2
+	Top    Align = 0
+	Middle Align = 4
+	Bottom Align = 8
+)
+
+// Style describes how text is painted
+type Style struct {
+	Color color.NRGBA
+
+	// Outline is width of the outline around glyphs in pixels, 0 means no outline
+	Outline      int
+	OutlineColor color.NRGBA
+
+	// Shadow is offset of the drop shadow, zero offset means no shadow
+	Shadow      image.Point
+	ShadowColor color.NRGBA
+
+	// Solid paints glyphs without anti-aliasing
+	Solid bool
+}
+
+// maxEntries limits number of cached strings. Least recently used strings are evicted first.
+const maxEntries = 256
+
+// Renderer paints text. Cached textures belong to the render.Renderer text is painted with, so
+// the same one is used for all calls, on the main thread rendering runs on.
+type Renderer struct {
+	entries map[key]*entry
+	clock   int
+}
+
+type key struct {
+	font    render.Font
+	text    string
+	outline int
+	solid   bool
+}
+
+// entry is a string rendered in white, so it can be painted in any color with tint
+type entry struct {
+	texture render.Texture
+	w, h    int
+	used    int
+}
+
+// NewRenderer creates new Renderer
+func NewRenderer() *Renderer {
+	return &Renderer{entries: make(map[key]*entry)}
+}
+
+// Draw paints text aligned to point (x, y)
+func (tr *Renderer) Draw(r render.Renderer, font *res.Font, text string, style Style, x, y int, align Align) error {
+	if text == "" {
+		return nil
+	}
+
+	e, err := tr.get(r, font.Font, text, 0, style.Solid)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case align&Right != 0:
+		x -= e.w
+	case align&Center != 0:
+		x -= e.w / 2
+	}
+	switch {
+	case align&Bottom != 0:
+		y -= e.h
+	case align&Middle != 0:
+		y -= e.h / 2
+	}
+
+	return tr.draw(r, font.Font, text, style, &image.Rectangle{Min: image.Pt(x, y), Max: image.Pt(x+e.w, y+e.h)})
+}
+
+// DrawStretched paints text stretched to fill rect
+func (tr *Renderer) DrawStretched(r render.Renderer, font *res.Font, text string, style Style, rect *image.Rectangle) error {
+	if text == "" {
+		return nil
+	}
+
+	return tr.draw(r, font.Font, text, style, rect)
+}
+
+// Size returns size of the text painted with font
+func (tr *Renderer) Size(font *res.Font, text string) (w, h int, err error) {
+	return font.Size(text)
+}
+
+// Destroy frees all cached textures
+func (tr *Renderer) Destroy() {
+	for k, e := range tr.entries {
+		e.texture.Destroy()
+		delete(tr.entries, k)
+	}
+}
+
+// draw paints shadow, outline and glyphs of the text in that order. Outline and shadow are
+// scaled like the glyphs when the text is stretched.
+func (tr *Renderer) draw(r render.Renderer, font render.Font, text string, style Style, rect *image.Rectangle) error {
+	e, err := tr.get(r, font, text, 0, style.Solid)
+	if err != nil {
+		return err
+	}
+
+	var outline *entry
+	var ox, oy int
+	if style.Outline > 0 {
+		outline, err = tr.get(r, font, text, style.Outline, style.Solid)
+		if err != nil {
+			return err
+		}
+		ox = style.Outline * rect.Dx() / e.w
+		oy = style.Outline * rect.Dy() / e.h
+	}
+
+	paint := func(e *entry, c color.NRGBA, dx, dy, grow int) error {
+		dst := &image.Rectangle{
+			Min: image.Pt(rect.Min.X+dx-grow*ox, rect.Min.Y+dy-grow*oy),
+			Max: image.Pt(rect.Max.X+dx+grow*ox, rect.Max.Y+dy+grow*oy),
+		}
+		e.texture.SetTint(c)
+		if err := r.DrawTexture(e.texture, nil, dst); err != nil {
+			return fmt.Errorf("could not copy text: %v", err)
+		}
+		return nil
+	}
+
+	if style.Shadow.X != 0 || style.Shadow.Y != 0 {
+		shadow, grow := e, 0
+		if outline != nil {
+			shadow, grow = outline, 1
+		}
+		if err := paint(shadow, style.ShadowColor, style.Shadow.X, style.Shadow.Y, grow); err != nil {
+			return err
+		}
+	}
+
+	if outline != nil {
+		if err := paint(outline, style.OutlineColor, 0, 0, 1); err != nil {
+			return err
+		}
+	}
+
+	return paint(e, style.Color, 0, 0, 0)
+}
+
+// get returns the cached text or renders it
+func (tr *Renderer) get(r render.Renderer, font render.Font, text string, outline int, solid bool) (*entry, error) {
+	tr.clock++
+
+	k := key{font: font, text: text, outline: outline, solid: solid}
+	if e, ok := tr.entries[k]; ok {
+		e.used = tr.clock
+		return e, nil
+	}
+
+	if len(tr.entries) >= maxEntries {
+		tr.evict()
+	}
+
+	texture, err := r.TextTexture(font, text, outline, solid)
+	if err != nil {
+		return nil, err
+	}
+	w, h := texture.Size()
+	e := &entry{texture: texture, w: w, h: h, used: tr.clock}
+	tr.entries[k] = e
+
+	return e, nil
+}
+
+// evict destroys the least recently used half of the cache
+func (tr *Renderer) evict() {
+	threshold := tr.clock - maxEntries/2
+	for k, e := range tr.entries {
+		if e.used < threshold {
+			e.texture.Destroy()
+			delete(tr.entries, k)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Package transition animates switches between scenes. The outgoing scene is rendered to a
+// texture once, the incoming one is rendered every frame, so it stays live while the textures
+// are blended.
+package transition
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/spoof/go-flappybird/render"
+)
+
+// Kind is a way to blend scenes
+type Kind string
+
+// Kinds of transitions
+const (
+	// None switches scenes at once
+	None Kind = "none"
+	// Fade fades the incoming scene in over the outgoing one
+	Fade Kind = "fade"
+	// Slide pushes the outgoing scene out to the left by the incoming one
+	Slide Kind = "slide"
+	// Iris opens the incoming scene in a growing circle
+	Iris Kind = "iris"
+)
+
+// irisStep is height of the strips the iris circle is made of
+const irisStep = 2
+
+// Scene renders a scene without presenting it
+type Scene interface {
+	Render(r render.Renderer) error
+}
+
+// Transition blends two scenes. It keeps its textures between transitions, so it's created
+// once per renderer.
+type Transition struct {
+	width  int
+	height int
+	from   render.Texture
+	to     render.Texture
+
+	kind     Kind
+	duration time.Duration
+	reverse  bool
+	start    time.Time
+}
+
+// New creates new Transition for scenes of size width x height painted by r
+func New(r render.Renderer, width, height int) (*Transition, error) {
+	from, err := r.NewTarget(width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := r.NewTarget(width, height)
+	if err != nil {
+		from.Destroy()
+		return nil, err
+	}
+
+	return &Transition{width: width, height: height, from: from, to: to}, nil
+}
+
+// Capture renders the outgoing scene before it's switched
+func (t *Transition) Capture(r render.Renderer, from Scene) error {
+	if err := capture(r, t.from, from); err != nil {
+		return fmt.Errorf("could not paint outgoing scene: %v", err)
+	}
+	return nil
+}
+
+// Begin starts transition of kind from the captured scene to another lasting for duration.
+// Reverse plays the transition backwards, e.g. the incoming scene slides in from the left, which
+// suits going back to a previous scene.
+func (t *Transition) Begin(kind Kind, duration time.Duration, reverse bool) {
+	t.kind = kind
+	t.duration = duration
+	t.reverse = reverse
+	t.start = time.Now()
+}
+
+// Paint paints the current frame of the transition to the incoming scene without presenting it.
+// It reports whether the transition is over.
+func (t *Transition) Paint(r render.Renderer, to Scene) (done bool, err error) {
+	if err := capture(r, t.to, to); err != nil {
+		return false, fmt.Errorf("could not paint incoming scene: %v", err)
+	}
+
+	p := 1.0
+	if t.duration > 0 {
+		p = math.Min(1, float64(time.Since(t.start))/float64(t.duration))
+	}
+	// smoothstep makes the transition start and end slowly
+	p = p * p * (3 - 2*p)
+
+	switch t.kind {
+	case Fade:
+		err = t.fade(r, p)
+	case Slide:
+		err = t.slide(r, p)
+	case Iris:
+		err = t.iris(r, p)
+	default:
+		err = r.DrawTexture(t.to, nil, nil)
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not paint transition: %v", err)
+	}
+
+	return p >= 1, nil
+}
+
+// Destroy frees textures of the transition
+func (t *Transition) Destroy() {
+	t.from.Destroy()
+	t.to.Destroy()
+}
+
+func (t *Transition) fade(r render.Renderer, p float64) error {
+	if err := r.DrawTexture(t.from, nil, nil); err != nil {
+		return err
+	}
+
+	t.to.SetTint(color.NRGBA{R: 255, G: 255, B: 255, A: uint8(p * 255)})
+	defer t.to.SetTint(render.White)
+	return r.DrawTexture(t.to, nil, nil)
+}
+
+func (t *Transition) slide(r render.Renderer, p float64) error {
+	offset := int(p * float64(t.width))
+	fromX, toX := -offset, t.width-offset
+	if t.reverse {
+		fromX, toX = offset, offset-t.width
+	}
+
+	if err := r.DrawTexture(t.from, nil, &image.Rectangle{Min: image.Pt(fromX, 0), Max: image.Pt(fromX+t.width, t.height)}); err != nil {
+		return err
+	}
+	return r.DrawTexture(t.to, nil, &image.Rectangle{Min: image.Pt(toX, 0), Max: image.Pt(toX+t.width, t.height)})
+}
+
+// iris paints the inner scene in a circle over the outer one. The circle grows from the center
+// with the incoming scene inside, in reverse it shrinks with the outgoing scene inside.
+func (t *Transition) iris(r render.Renderer, p float64) error {
+	outer, inner := t.from, t.to
+	if t.reverse {
+		outer, inner = t.to, t.from
+		p = 1 - p
+	}
+
+	if err := r.DrawTexture(outer, nil, nil); err != nil {
+		return err
+	}
+
+	cx, cy := float64(t.width)/2, float64(t.height)/2
+	radius := p * math.Hypot(cx, cy)
+	for y := 0; y < t.height; y += irisStep {
+		dy := float64(y) + irisStep/2 - cy
+		if math.Abs(dy) >= radius {
+			continue
+		}
+
+		half := math.Sqrt(radius*radius - dy*dy)
+		x0 := int(math.Max(0, cx-half))
+		x1 := int(math.Min(float64(t.width), cx+half))
+		strip := &image.Rectangle{Min: image.Pt(x0, y), Max: image.Pt(x1, y+irisStep)}
+		if err := r.DrawTexture(inner, strip, strip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// capture renders s to texture t
+func capture(r render.Renderer, t render.Texture, s Scene) error {
+	if err := r.SetTarget(t); err != nil {
+		return fmt.Errorf("could not set render target: %v", err)
+	}
+	defer r.SetTarget(nil)
+
+	if err := r.Clear(color.NRGBA{A: 255}); err != nil {
+		return err
+	}
+	return s.Render(r)
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"image"
+
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/scene/text"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Screen is a set of widgets covering the logical screen. It tracks which widget is focused and
+// which is pressed.
+type Screen struct {
+	Palette Palette
+
+	rect    image.Rectangle
+	text    *text.Renderer
+	widgets []Widget
+	focus   Interactive
+	pressed Interactive
+}
+
+// NewScreen creates new empty Screen of the size
+func NewScreen(width, height int) *Screen {
+	return &Screen{
+		Palette: DefaultPalette,
+		rect:    image.Rect(0, 0, width, height),
+		text:    text.NewRenderer(),
+	}
+}
+
+// Add adds widgets to the screen. They are painted in order they are added. The first widget
+// player can focus gets the focus.
+func (s *Screen) Add(widgets ...Widget) {
+	s.widgets = append(s.widgets, widgets...)
+	if s.focus == nil {
+		s.moveFocus(1)
+	}
+}
+
+// Focus focuses the widget
+func (s *Screen) Focus(w Interactive) {
+	s.focus = w
+}
+
+// Focused returns the focused widget, it's nil if the screen has nothing to focus
+func (s *Screen) Focused() Interactive {
+	return s.focus
+}
+
+// Text returns text renderer of the screen, so scenes can paint their own text with its cache
+func (s *Screen) Text() *text.Renderer {
+	return s.text
+}
+
+// HandleEvent moves focus, presses and clicks widgets. It reports whether the event was used, so
+// scenes handle only events which aren't meant for widgets.
+func (s *Screen) HandleEvent(e input.Event) bool {
+	p := image.Pt(int(e.X), int(e.Y))
+	switch event := e.SDL.(type) {
+	case *sdl.MouseMotionEvent:
+		if w := s.at(p); w != nil {
+			s.focus = w
+			return true
+		}
+		return false
+
+	case *sdl.MouseButtonEvent:
+		if event.Button != sdl.BUTTON_LEFT {
+			return false
+		}
+		return s.point(event.Type == sdl.MOUSEBUTTONDOWN, p)
+
+	case *sdl.TouchFingerEvent:
+		if event.Type == sdl.FINGERMOTION {
+			return false
+		}
+		return s.point(event.Type == sdl.FINGERDOWN, p)
+	}
+
+	key := NavKey(e.SDL)
+	if e.Actions.Has(input.Confirm) {
+		key = sdl.K_RETURN
+	}
+	if key == sdl.K_UNKNOWN {
+		return false
+	}
+
+	if s.focus != nil && s.focus.key(key) {
+		return true
+	}
+
+	switch key {
+	case sdl.K_UP:
+		s.moveFocus(-1)
+		return true
+	case sdl.K_DOWN, sdl.K_TAB:
+		s.moveFocus(1)
+		return true
+	}
+
+	return false
+}
+
+// Paint paints all widgets of the screen without presenting them
+func (s *Screen) Paint(r render.Renderer) error {
+	for _, w := range s.widgets {
+		state := Normal
+		if iw, ok := w.(Interactive); ok {
+			switch {
+			case iw == s.pressed:
+				state = Pressed
+			case iw == s.focus:
+				state = Focused
+			}
+		}
+
+		if err := w.paint(r, s, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Destroy frees all resources of the screen
+func (s *Screen) Destroy() {
+	s.text.Destroy()
+}
+
+// point handles press or release of mouse button or finger. Widgets are clicked when pointer is
+// released over the widget it was pressed on.
+func (s *Screen) point(down bool, p image.Point) bool {
+	w := s.at(p)
+	if down {
+		s.pressed = w
+		if w != nil {
+			s.focus = w
+		}
+		return w != nil
+	}
+
+	pressed := s.pressed
+	s.pressed = nil
+	if pressed != nil && pressed == w {
+		w.click(p)
+	}
+	return pressed != nil
+}
+
+// at returns widget player can focus under the point
+func (s *Screen) at(p image.Point) Interactive {
+	for i := len(s.widgets) - 1; i >= 0; i-- {
+		w, ok := s.widgets[i].(Interactive)
+		if ok && w.focusable() && p.In(w.Bounds()) {
+			return w
+		}
+	}
+	return nil
+}
+
+func (s *Screen) moveFocus(delta int) {
+	var widgets []Interactive
+	current := -1
+	for _, w := range s.widgets {
+		if iw, ok := w.(Interactive); ok && iw.focusable() {
+			if iw == s.focus {
+				current = len(widgets)
+			}
+			widgets = append(widgets, iw)
+		}
+	}
+
+	if len(widgets) == 0 {
+		return
+	}
+
+	if current < 0 {
+		s.focus = widgets[0]
+		return
+	}
+	s.focus = widgets[(current+delta+len(widgets))%len(widgets)]
+}
+
+func (s *Screen) style(state State) text.Style {
+	return text.Style{
+		Color:       s.Palette.color(state),
+		Shadow:      image.Pt(2, 2),
+		ShadowColor: s.Palette.Shadow,
+	}
+}
@@ -0,0 +1,158 @@
+// Package ui implements widgets menus are built of. Widgets are placed relative to the screen by
+// anchors, navigated with keyboard or game controller and clicked with mouse or touch.
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/spoof/go-flappybird/render"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Anchor is a point of the screen a widget is attached to. The same point of the widget is placed
+// there, e.g. a widget anchored to Bottom touches the bottom edge and is centered horizontally.
+type Anchor int
+
+// Anchors
+const (
+	TopLeft Anchor = iota
+	Top
+	TopRight
+	Left
+	Center
+	Right
+	BottomLeft
+	Bottom
+	BottomRight
+)
+
+// Layout places a widget on the screen
+type Layout struct {
+	Anchor Anchor
+
+	// X and Y offset the widget from its anchor
+	X int
+	Y int
+
+	// W and H are size of the widget, zero means size of its content
+	W int
+	H int
+}
+
+// place returns bounds of a widget with content of size w x h inside parent
+func (l Layout) place(parent image.Rectangle, w, h int) image.Rectangle {
+	if l.W > 0 {
+		w = l.W
+	}
+	if l.H > 0 {
+		h = l.H
+	}
+
+	col, row := int(l.Anchor%3), int(l.Anchor/3)
+	min := image.Pt(
+		parent.Min.X+(parent.Dx()-w)*col/2+l.X,
+		parent.Min.Y+(parent.Dy()-h)*row/2+l.Y,
+	)
+	return image.Rectangle{Min: min, Max: min.Add(image.Pt(w, h))}
+}
+
+// State is state of a widget relative to the player
+type State int
+
+// States of widgets
+const (
+	Normal State = iota
+	Focused
+	Pressed
+)
+
+// Palette holds colors of widgets in every state
+type Palette struct {
+	Normal  color.NRGBA
+	Focused color.NRGBA
+	Pressed color.NRGBA
+	Shadow  color.NRGBA
+}
+
+// DefaultPalette is palette of the classic game
+var DefaultPalette = Palette{
+	Normal:  color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+	Focused: color.NRGBA{R: 255, G: 100, B: 0, A: 255},
+	Pressed: color.NRGBA{R: 200, G: 60, B: 0, A: 255},
+	Shadow:  color.NRGBA{R: 0, G: 0, B: 0, A: 160},
+}
+
+func (p Palette) color(state State) color.NRGBA {
+	switch state {
+	case Focused:
+		return p.Focused
+	case Pressed:
+		return p.Pressed
+	}
+	return p.Normal
+}
+
+// Widget is an element of the screen
+type Widget interface {
+	// Bounds returns area of the screen the widget occupies. It's valid after the screen has
+	// been painted.
+	Bounds() image.Rectangle
+
+	// paint places the widget inside the screen and paints it
+	paint(r render.Renderer, s *Screen, state State) error
+}
+
+// Interactive is a widget player can focus and click
+type Interactive interface {
+	Widget
+
+	// key handles a navigation key, see NavKey. It reports whether the key was used.
+	key(k sdl.Keycode) bool
+
+	// click handles a click at the point of the screen
+	click(p image.Point)
+
+	// focusable reports whether the widget takes focus at the moment
+	focusable() bool
+}
+
+// NavKey returns key which navigates menus. Controller buttons are mapped to keys, so menus
+// don't depend on bindings player may have broken.
+func NavKey(event sdl.Event) sdl.Keycode {
+	switch e := event.(type) {
+	case *sdl.KeyboardEvent:
+		if e.Type == sdl.KEYDOWN {
+			switch e.Keysym.Sym {
+			case sdl.K_BACKSPACE:
+				return sdl.K_DELETE
+			case sdl.K_KP_ENTER:
+				return sdl.K_RETURN
+			}
+			return e.Keysym.Sym
+		}
+	case *sdl.ControllerButtonEvent:
+		if e.Type == sdl.CONTROLLERBUTTONDOWN {
+			switch e.Button {
+			case sdl.CONTROLLER_BUTTON_DPAD_UP:
+				return sdl.K_UP
+			case sdl.CONTROLLER_BUTTON_DPAD_DOWN:
+				return sdl.K_DOWN
+			case sdl.CONTROLLER_BUTTON_DPAD_LEFT:
+				return sdl.K_LEFT
+			case sdl.CONTROLLER_BUTTON_DPAD_RIGHT:
+				return sdl.K_RIGHT
+			case sdl.CONTROLLER_BUTTON_A:
+				return sdl.K_RETURN
+			case sdl.CONTROLLER_BUTTON_X:
+				return sdl.K_DELETE
+			case sdl.CONTROLLER_BUTTON_Y:
+				return sdl.K_r
+			case sdl.CONTROLLER_BUTTON_B:
+				return sdl.K_ESCAPE
+			}
+		}
+	}
+
+	return sdl.K_UNKNOWN
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestLayoutPlace(t *testing.T) {
+	parent := image.Rect(0, 0, 800, 600)
+
+	tests := []struct {
+		name   string
+		layout Layout
+		want   image.Rectangle
+	}{
+		{"top left", Layout{Anchor: TopLeft}, image.Rect(0, 0, 100, 40)},
+		{"top", Layout{Anchor: Top, Y: 30}, image.Rect(350, 30, 450, 70)},
+		{"top right", Layout{Anchor: TopRight, X: -10}, image.Rect(690, 0, 790, 40)},
+		{"center", Layout{Anchor: Center}, image.Rect(350, 280, 450, 320)},
+		{"bottom", Layout{Anchor: Bottom, Y: -40}, image.Rect(350, 520, 450, 560)},
+		{"bottom right", Layout{Anchor: BottomRight}, image.Rect(700, 560, 800, 600)},
+		{"fixed size", Layout{Anchor: Top, W: 200, H: 80}, image.Rect(300, 0, 500, 80)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.layout.place(parent, 100, 40); got != tt.want {
+				t.Errorf("place() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutPlaceOffsetParent(t *testing.T) {
+	got := Layout{Anchor: Center}.place(image.Rect(100, 50, 300, 150), 20, 10)
+	if want := image.Rect(190, 95, 210, 105); got != want {
+		t.Errorf("place() = %v, want %v", got, want)
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	activated := -1
+	l := &List{Items: []string{"a", "b", "c"}, RowHeight: 20, OnActivate: func(i int) { activated = i }}
+
+	steps := []struct {
+		key      sdl.Keycode
+		used     bool
+		selected int
+	}{
+		{sdl.K_DOWN, true, 1},
+		{sdl.K_DOWN, true, 2},
+		{sdl.K_DOWN, false, 2},
+		{sdl.K_UP, true, 1},
+		{sdl.K_RETURN, true, 1},
+	}
+	for i, s := range steps {
+		if used := l.key(s.key); used != s.used || l.Selected != s.selected {
+			t.Errorf("step %d: key() = %v selecting %d, want %v selecting %d", i, used, l.Selected, s.used, s.selected)
+		}
+	}
+	if activated != 1 {
+		t.Errorf("activated row %d, want 1", activated)
+	}
+
+	l.bounds = image.Rect(0, 100, 200, 160)
+	l.click(image.Pt(10, 145))
+	if l.Selected != 2 || activated != 2 {
+		t.Errorf("click has selected %d and activated %d, want 2", l.Selected, activated)
+	}
+}
+
+func TestSlider(t *testing.T) {
+	var changed []float64
+	sl := &Slider{Value: 0.5, Min: 0, Max: 1, Step: 0.1, OnChange: func(v float64) { changed = append(changed, v) }}
+	sl.bar = image.Rect(100, 0, 200, 8)
+
+	sl.key(sdl.K_LEFT)
+	sl.key(sdl.K_LEFT)
+	sl.click(image.Pt(173, 4))
+	sl.click(image.Pt(50, 4))
+	sl.click(image.Pt(199, 4))
+	sl.key(sdl.K_RIGHT)
+
+	want := []float64{0.4, 0.3, 0.7, 1}
+	if len(changed) != len(want) {
+		t.Fatalf("values are %v, want %v", changed, want)
+	}
+	for i := range want {
+		if changed[i] != want[i] {
+			t.Fatalf("values are %v, want %v", changed, want)
+		}
+	}
+}
@@ -0,0 +1,465 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/scene/text"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Label is a text player can't interact with
+type Label struct {
+	Layout
+	Font *res.Font
+	Text string
+
+	// Style of the text. Normal color of the palette is used if style has no color.
+	Style text.Style
+
+	// Stretch stretches the text to the size of the layout
+	Stretch bool
+
+	bounds image.Rectangle
+}
+
+// Bounds implements Widget
+func (l *Label) Bounds() image.Rectangle {
+	return l.bounds
+}
+
+func (l *Label) paint(r render.Renderer, s *Screen, state State) error {
+	w, h, err := s.text.Size(l.Font, l.Text)
+	if err != nil {
+		return err
+	}
+	l.bounds = l.place(s.rect, w, h)
+
+	style := l.Style
+	if style.Color == (color.NRGBA{}) {
+		style.Color = s.Palette.Normal
+	}
+
+	if l.Stretch {
+		return s.text.DrawStretched(r, l.Font, l.Text, style, &l.bounds)
+	}
+	return drawCentered(r, s, l.Font, l.Text, style, l.bounds)
+}
+
+// Button is a text which calls OnClick when it's clicked or activated with Enter
+type Button struct {
+	Layout
+	Font    *res.Font
+	Text    string
+	OnClick func()
+
+	bounds image.Rectangle
+}
+
+// Bounds implements Widget
+func (b *Button) Bounds() image.Rectangle {
+	return b.bounds
+}
+
+func (b *Button) paint(r render.Renderer, s *Screen, state State) error {
+	w, h, err := s.text.Size(b.Font, b.Text)
+	if err != nil {
+		return err
+	}
+	b.bounds = b.place(s.rect, w, h)
+
+	return drawCentered(r, s, b.Font, b.Text, s.style(state), b.bounds)
+}
+
+func (b *Button) key(k sdl.Keycode) bool {
+	if k != sdl.K_RETURN {
+		return false
+	}
+	b.click(image.Point{})
+	return true
+}
+
+func (b *Button) click(p image.Point) {
+	if b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *Button) focusable() bool {
+	return true
+}
+
+// columnGap is the space between columns of a List
+const columnGap = 24
+
+// List is a column of text rows, one of which can be selected. List with OnActivate takes focus:
+// Up and Down change selection, Enter or click activate the row.
+type List struct {
+	Layout
+	Font *res.Font
+
+	// Items are rows of the list. Cells of a row are separated by tabs, they are aligned in
+	// columns as wide as their widest cells.
+	Items []string
+
+	// RowHeight is distance between rows, line height of the font is used if it's zero
+	RowHeight int
+
+	// Selected is index of the highlighted row, -1 highlights nothing
+	Selected int
+
+	// Empty is shown when there are no rows
+	Empty string
+
+	OnActivate func(i int)
+
+	bounds image.Rectangle
+}
+
+// Bounds implements Widget
+func (l *List) Bounds() image.Rectangle {
+	return l.bounds
+}
+
+func (l *List) rowHeight() int {
+	if l.RowHeight > 0 {
+		return l.RowHeight
+	}
+	return l.Font.LineSkip()
+}
+
+func (l *List) paint(r render.Renderer, s *Screen, state State) error {
+	rows := l.Items
+	if len(rows) == 0 && l.Empty != "" {
+		rows = []string{l.Empty}
+	}
+
+	cells := make([][]string, len(rows))
+	var columns []int
+	var width int
+	for i, row := range rows {
+		cells[i] = strings.Split(row, "\t")
+		if len(cells[i]) == 1 {
+			w, _, err := s.text.Size(l.Font, row)
+			if err != nil {
+				return err
+			}
+			if w > width {
+				width = w
+			}
+			continue
+		}
+
+		for j, cell := range cells[i] {
+			w, _, err := s.text.Size(l.Font, cell)
+			if err != nil {
+				return err
+			}
+			if j == len(columns) {
+				columns = append(columns, 0)
+			}
+			if w > columns[j] {
+				columns[j] = w
+			}
+		}
+	}
+	if len(columns) > 0 {
+		w := (len(columns) - 1) * columnGap
+		for _, c := range columns {
+			w += c
+		}
+		if w > width {
+			width = w
+		}
+	}
+	l.bounds = l.place(s.rect, width, len(rows)*l.rowHeight())
+
+	for i, row := range rows {
+		rowState := Normal
+		if i == l.Selected && len(l.Items) > 0 {
+			rowState = Focused
+			if state == Pressed {
+				rowState = Pressed
+			}
+		}
+
+		y := l.bounds.Min.Y + i*l.rowHeight()
+		rect := image.Rect(l.bounds.Min.X, y, l.bounds.Max.X, y+l.rowHeight())
+		if len(cells[i]) == 1 {
+			if err := drawCentered(r, s, l.Font, row, s.style(rowState), rect); err != nil {
+				return err
+			}
+			continue
+		}
+
+		x := rect.Min.X + (rect.Dx()-width)/2
+		for j, cell := range cells[i] {
+			if err := s.text.Draw(r, l.Font, cell, s.style(rowState), x, rect.Min.Y+rect.Dy()/2, text.Left|text.Middle); err != nil {
+				return err
+			}
+			x += columns[j] + columnGap
+		}
+	}
+
+	return nil
+}
+
+func (l *List) key(k sdl.Keycode) bool {
+	switch k {
+	case sdl.K_UP:
+		if l.Selected > 0 {
+			l.Selected--
+			return true
+		}
+	case sdl.K_DOWN:
+		if l.Selected < len(l.Items)-1 {
+			l.Selected++
+			return true
+		}
+	case sdl.K_RETURN:
+		if l.Selected >= 0 && l.Selected < len(l.Items) {
+			l.OnActivate(l.Selected)
+			return true
+		}
+	}
+	return false
+}
+
+func (l *List) click(p image.Point) {
+	i := (p.Y - l.bounds.Min.Y) / l.rowHeight()
+	if i >= 0 && i < len(l.Items) {
+		l.Selected = i
+		l.OnActivate(i)
+	}
+}
+
+func (l *List) focusable() bool {
+	return l.OnActivate != nil
+}
+
+// Toggle is an option which is either on or off. It's switched by click, Enter, Left or Right.
+type Toggle struct {
+	Layout
+	Font     *res.Font
+	Text     string
+	On       bool
+	OnChange func(on bool)
+
+	bounds image.Rectangle
+}
+
+// Bounds implements Widget
+func (t *Toggle) Bounds() image.Rectangle {
+	return t.bounds
+}
+
+func (t *Toggle) label() string {
+	if t.On {
+		return t.Text + ": On"
+	}
+	return t.Text + ": Off"
+}
+
+func (t *Toggle) paint(r render.Renderer, s *Screen, state State) error {
+	label := t.label()
+	w, h, err := s.text.Size(t.Font, label)
+	if err != nil {
+		return err
+	}
+	t.bounds = t.place(s.rect, w, h)
+
+	return drawCentered(r, s, t.Font, label, s.style(state), t.bounds)
+}
+
+func (t *Toggle) key(k sdl.Keycode) bool {
+	switch k {
+	case sdl.K_RETURN, sdl.K_LEFT, sdl.K_RIGHT:
+		t.click(image.Point{})
+		return true
+	}
+	return false
+}
+
+func (t *Toggle) click(p image.Point) {
+	t.On = !t.On
+	if t.OnChange != nil {
+		t.OnChange(t.On)
+	}
+}
+
+func (t *Toggle) focusable() bool {
+	return true
+}
+
+// Choice is an option with several values. Right, Enter or click select the next value, Left
+// selects the previous one.
+type Choice struct {
+	Layout
+	Font     *res.Font
+	Text     string
+	Options  []string
+	Selected int
+	OnChange func(i int)
+
+	bounds image.Rectangle
+}
+
+// Bounds implements Widget
+func (c *Choice) Bounds() image.Rectangle {
+	return c.bounds
+}
+
+func (c *Choice) label() string {
+	if c.Selected < 0 || c.Selected >= len(c.Options) {
+		return c.Text + ": -"
+	}
+	return c.Text + ": " + c.Options[c.Selected]
+}
+
+func (c *Choice) paint(r render.Renderer, s *Screen, state State) error {
+	label := c.label()
+	w, h, err := s.text.Size(c.Font, label)
+	if err != nil {
+		return err
+	}
+	c.bounds = c.place(s.rect, w, h)
+
+	return drawCentered(r, s, c.Font, label, s.style(state), c.bounds)
+}
+
+func (c *Choice) key(k sdl.Keycode) bool {
+	switch k {
+	case sdl.K_RETURN, sdl.K_RIGHT:
+		c.selectNext(1)
+		return true
+	case sdl.K_LEFT:
+		c.selectNext(-1)
+		return true
+	}
+	return false
+}
+
+func (c *Choice) click(p image.Point) {
+	c.selectNext(1)
+}
+
+func (c *Choice) selectNext(delta int) {
+	if len(c.Options) == 0 {
+		return
+	}
+
+	c.Selected = (c.Selected + delta + len(c.Options)) % len(c.Options)
+	if c.OnChange != nil {
+		c.OnChange(c.Selected)
+	}
+}
+
+func (c *Choice) focusable() bool {
+	return true
+}
+
+const (
+	sliderWidth  = 160
+	sliderHeight = 8
+	sliderGap    = 16
+)
+
+// Slider is a value in range Min..Max changed by Step with Left and Right, or set by clicking
+// its bar.
+type Slider struct {
+	Layout
+	Font     *res.Font
+	Text     string
+	Value    float64
+	Min      float64
+	Max      float64
+	Step     float64
+	OnChange func(v float64)
+
+	bounds image.Rectangle
+	bar    image.Rectangle
+}
+
+// Bounds implements Widget
+func (sl *Slider) Bounds() image.Rectangle {
+	return sl.bounds
+}
+
+func (sl *Slider) paint(r render.Renderer, s *Screen, state State) error {
+	w, h, err := s.text.Size(sl.Font, sl.Text)
+	if err != nil {
+		return err
+	}
+	sl.bounds = sl.place(s.rect, w+sliderGap+sliderWidth, h)
+	y := sl.bounds.Min.Y + sl.bounds.Dy()/2 - sliderHeight/2
+	sl.bar = image.Rect(sl.bounds.Max.X-sliderWidth, y, sl.bounds.Max.X, y+sliderHeight)
+
+	style := s.style(state)
+	if err := s.text.Draw(r, sl.Font, sl.Text, style, sl.bounds.Min.X, sl.bounds.Min.Y+sl.bounds.Dy()/2, text.Left|text.Middle); err != nil {
+		return err
+	}
+
+	if err := r.FillRect(&sl.bar, s.Palette.Shadow); err != nil {
+		return fmt.Errorf("could not paint slider: %v", err)
+	}
+
+	filled := sl.bar
+	if sl.Max > sl.Min {
+		filled.Max.X = filled.Min.X + int(float64(sl.bar.Dx())*(sl.Value-sl.Min)/(sl.Max-sl.Min))
+	}
+	if err := r.FillRect(&filled, style.Color); err != nil {
+		return fmt.Errorf("could not paint slider: %v", err)
+	}
+
+	return nil
+}
+
+func (sl *Slider) key(k sdl.Keycode) bool {
+	switch k {
+	case sdl.K_LEFT:
+		sl.set(sl.Value - sl.Step)
+		return true
+	case sdl.K_RIGHT:
+		sl.set(sl.Value + sl.Step)
+		return true
+	}
+	return false
+}
+
+func (sl *Slider) click(p image.Point) {
+	if p.X < sl.bar.Min.X {
+		return
+	}
+	v := sl.Min + float64(p.X-sl.bar.Min.X)/float64(sl.bar.Dx())*(sl.Max-sl.Min)
+	if sl.Step > 0 {
+		v = sl.Min + math.Round((v-sl.Min)/sl.Step)*sl.Step
+	}
+	sl.set(v)
+}
+
+func (sl *Slider) set(v float64) {
+	v = math.Max(sl.Min, math.Min(sl.Max, v))
+	// steps like 0.1 don't add up exactly, so the value is rounded to avoid 0.30000000000000004
+	v = math.Round(v*1e6) / 1e6
+	if v == sl.Value {
+		return
+	}
+
+	sl.Value = v
+	if sl.OnChange != nil {
+		sl.OnChange(v)
+	}
+}
+
+func (sl *Slider) focusable() bool {
+	return true
+}
+
+func drawCentered(r render.Renderer, s *Screen, font *res.Font, str string, style text.Style, rect image.Rectangle) error {
+	return s.text.Draw(r, font, str, style, rect.Min.X+rect.Dx()/2, rect.Min.Y+rect.Dy()/2, text.Center|text.Middle)
+}
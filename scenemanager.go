@@ -1,97 +1,584 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"image/color"
+	"log"
+	"os"
+	"time"
 
+	"github.com/spoof/go-flappybird/audio"
+	"github.com/spoof/go-flappybird/config"
+	"github.com/spoof/go-flappybird/input"
+	"github.com/spoof/go-flappybird/render"
+	"github.com/spoof/go-flappybird/replay"
+	"github.com/spoof/go-flappybird/res"
+	"github.com/spoof/go-flappybird/save"
 	"github.com/spoof/go-flappybird/scene"
+	"github.com/spoof/go-flappybird/scene/transition"
 	"github.com/veandco/go-sdl2/sdl"
 )
 
-// Scene is an interface for all scenes
-type Scene interface {
-	Run(<-chan sdl.Event, *sdl.Renderer) <-chan scene.Event
-	Destroy()
+// fallbackFrameRate is used when renderer doesn't wait for vertical sync
+const fallbackFrameRate = 60
+
+// Paths tells where the game keeps its files
+type Paths struct {
+	// Config is the config file settings are saved to
+	Config string
+	// Controls is the file with bindings
+	Controls string
+	// Themes is the directory with themes player can pick in settings
+	Themes string
+	// Replays is the directory replays are saved to
+	Replays string
 }
 
-// SceneManager represents main object for managing scenes
+// SceneManager represents main object for managing scenes. Scenes are registered by names and
+// kept in a stack: the top scene is shown, gets input and updates, scenes change the stack with
+// scene.PushEvent, scene.PopEvent and scene.ReplaceEvent. SceneManager runs the only loop of the
+// game on the main thread, scenes are driven by it.
 type SceneManager struct {
-	splash   *scene.Splash
-	game     *scene.Game
-	gameOver *scene.GameOver
+	scenes map[string]scene.Scene
+	music  map[string]audio.Music
+	stack  []*stackEntry
+
+	// scenes which events carry data the manager handles
+	settings    *scene.Settings
+	game        *scene.Game
+	gameOver    *scene.GameOver
+	leaderboard *scene.Leaderboard
+
+	store    *save.Store
+	saveData *save.Data
+	lastGame *scene.EndGameEvent
+	audio    *audio.Audio
+
+	cfg         config.Config
+	configPath  string
+	seed        int64
+	fixedSeed   bool
+	replaying   bool
+	windowModes chan bool
+
+	transition    *transition.Transition
+	transitioning bool
+}
 
-	currentScene Scene
-	sceneEvents  chan sdl.Event
-	sceneOutc    <-chan scene.Event
+type stackEntry struct {
+	name  string
+	scene scene.Scene
 }
 
-// NewSceneManager creates new SceneManager
-func NewSceneManager(r *sdl.Renderer, w, h int) (*SceneManager, error) {
-	splashScene, err := scene.NewSplash(r, w, h)
+// NewSceneManager creates new SceneManager which scenes get resources from rm. Controls scene
+// changes bindings of mapper, settings scene changes cfg, both save changes to paths. Player's
+// progress is loaded from and saved to store. Music and sounds of scenes are played with a.
+func NewSceneManager(rm *res.Manager, cfg config.Config, mapper *input.Mapper, paths Paths,
+	store *save.Store, a *audio.Audio) (*SceneManager, error) {
+	saveData, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("could not load save data: %v", err)
+	}
+
+	sm := &SceneManager{
+		scenes:      make(map[string]scene.Scene),
+		music:       make(map[string]audio.Music),
+		store:       store,
+		saveData:    saveData,
+		audio:       a,
+		cfg:         cfg,
+		configPath:  paths.Config,
+		windowModes: make(chan bool, 1),
+	}
+	if err := sm.registerScenes(rm, cfg, mapper, paths); err != nil {
+		sm.Destroy()
+		return nil, err
+	}
+
+	return sm, nil
+}
+
+// registerScenes creates and registers all scenes of the game
+func (sm *SceneManager) registerScenes(rm *res.Manager, cfg config.Config, mapper *input.Mapper, paths Paths) error {
+	w, h := cfg.WindowWidth, cfg.WindowHeight
+
+	splashScene, err := scene.NewSplash(rm, w, h)
+	if err != nil {
+		return fmt.Errorf("could not create Splash scene %v", err)
+	}
+	sm.Register(scene.SplashScene, splashScene, audio.MenuMusic)
+
+	menuScene, err := scene.NewMenu(rm, w, h)
+	if err != nil {
+		return fmt.Errorf("could not create Menu scene %v", err)
+	}
+	sm.Register(scene.MenuScene, menuScene, audio.MenuMusic)
+
+	modesScene, err := scene.NewModes(rm, w, h)
 	if err != nil {
-		return nil, fmt.Errorf("could not create Splash scene %v", err)
+		return fmt.Errorf("could not create Modes scene %v", err)
 	}
+	sm.Register(scene.ModesScene, modesScene, audio.MenuMusic)
 
-	gameScene, err := scene.NewGame(r, w, h)
+	themes, err := res.FindThemes(paths.Themes)
 	if err != nil {
-		return nil, fmt.Errorf("could not create Game scene %v", err)
+		log.Printf("could not find themes: %v", err)
 	}
 
-	gameOverScene, err := scene.NewGameOver(r, w, h)
+	sm.settings, err = scene.NewSettings(rm, w, h, cfg, themes, sm.audio)
 	if err != nil {
-		return nil, fmt.Errorf("could not create Gamve Over scene%v", err)
+		return fmt.Errorf("could not create Settings scene %v", err)
 	}
+	sm.Register(scene.SettingsScene, sm.settings, audio.MenuMusic)
 
-	return &SceneManager{
-		splash:   splashScene,
-		game:     gameScene,
-		gameOver: gameOverScene,
-	}, nil
+	sm.game, err = scene.NewGame(rm, w, h, cfg.BirdX, cfg.Physics, sm.audio)
+	if err != nil {
+		return fmt.Errorf("could not create Game scene %v", err)
+	}
+	sm.game.SetBestScore(sm.saveData.BestScore)
+	sm.Register(scene.GameScene, sm.game, audio.GameMusic)
+
+	sm.gameOver, err = scene.NewGameOver(rm, w, h, paths.Replays)
+	if err != nil {
+		return fmt.Errorf("could not create Gamve Over scene%v", err)
+	}
+	sm.Register(scene.GameOverScene, sm.gameOver, audio.MenuMusic)
+
+	controlsScene, err := scene.NewControls(rm, w, h, mapper, paths.Controls)
+	if err != nil {
+		return fmt.Errorf("could not create Controls scene %v", err)
+	}
+	sm.Register(scene.ControlsScene, controlsScene, audio.MenuMusic)
+
+	pauseScene, err := scene.NewPause(rm, w, h)
+	if err != nil {
+		return fmt.Errorf("could not create Pause scene %v", err)
+	}
+	sm.Register(scene.PauseScene, pauseScene, "")
+
+	sm.leaderboard, err = scene.NewLeaderboard(rm, w, h, sm.saveData)
+	if err != nil {
+		return fmt.Errorf("could not create Leaderboard scene %v", err)
+	}
+	sm.Register(scene.LeaderboardScene, sm.leaderboard, audio.MenuMusic)
+
+	return nil
 }
 
-// Run starts the loop
-func (sm *SceneManager) Run(events <-chan sdl.Event, renderer *sdl.Renderer) <-chan error {
-	errc := make(chan error)
+// Register adds scene s under the name, so other scenes can push it. Music is played while the
+// scene is shown, empty music pauses the current track. SceneManager destroys registered
+// scenes.
+func (sm *SceneManager) Register(name string, s scene.Scene, music audio.Music) {
+	sm.scenes[name] = s
+	sm.music[name] = music
+}
 
-	go func() {
-		defer close(errc)
+// PollFunc returns input received since the last call
+type PollFunc func() ([]input.Event, error)
 
-		sm.currentScene = sm.splash
-		sm.sceneEvents = make(chan sdl.Event)
-		sceneOutc := sm.currentScene.Run(sm.sceneEvents, renderer)
+// Run runs the loop of the game until ctx is cancelled or player quits. SDL expects its calls
+// from the main thread, so Run must be called on it: every frame input is polled with poll,
+// the top scene handles it, gets updated, is rendered with renderer and presented on display by
+// the calling thread only. Frames are paced by vertical sync of the display.
+func (sm *SceneManager) Run(ctx context.Context, renderer render.Renderer, display render.Display, poll PollFunc) error {
+	var err error
+	sm.transition, err = transition.New(renderer, sm.cfg.WindowWidth, sm.cfg.WindowHeight)
+	if err != nil {
+		return fmt.Errorf("could not create transition: %v", err)
+	}
+
+	if err := sm.push(scene.SplashScene); err != nil {
+		return err
+	}
 
-		for {
+	frameDelay := time.Duration(0)
+	if !display.VSync() {
+		frameDelay = time.Second / fallbackFrameRate
+	}
+
+	last := time.Now()
+	for {
+		events, err := poll()
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		quit, err := sm.handleInput(renderer, events)
+		if quit || err != nil {
+			return err
+		}
+
+		now := time.Now()
+		quit, err = sm.frame(renderer, display, now.Sub(last))
+		if quit || err != nil {
+			return err
+		}
+		last = now
+
+		if frameDelay > 0 {
 			select {
-			case e, ok := <-events:
-				if !ok {
-					close(sm.sceneEvents)
-					<-sceneOutc
-					return
-				}
-				sm.sceneEvents <- e
-
-			case e := <-sceneOutc:
-				switch event := e.(type) {
-				case *scene.ErrorEvent:
-					errc <- fmt.Errorf("Error from scene %v", event.Err)
-
-				case *scene.StartGameEvent:
-					<-sceneOutc
-					sceneOutc = sm.game.Run(sm.sceneEvents, renderer)
-
-				case *scene.EndGameEvent:
-					<-sceneOutc
-					sm.gameOver.SetBestScore(event.BestScore)
-					sceneOutc = sm.gameOver.Run(sm.sceneEvents, renderer)
-				}
+			case <-ctx.Done():
+				return nil
+			case <-time.After(frameDelay - time.Since(now)):
 			}
 		}
-	}()
+	}
+}
+
+// handleInput passes events to the top scene. It reports whether the game should quit.
+func (sm *SceneManager) handleInput(renderer render.Renderer, events []input.Event) (bool, error) {
+	for _, e := range events {
+		if e.Actions.Has(input.Quit) && !sm.capturing() {
+			return true, nil
+		}
+		if e.Actions.Has(input.Mute) && !sm.capturing() {
+			sm.audio.ToggleMute()
+			continue
+		}
+		quit, err := sm.dispatch(renderer, sm.top().scene.HandleEvent(e))
+		if quit || err != nil {
+			return quit, err
+		}
+	}
+
+	return false, nil
+}
+
+// capturing reports whether keys are taken by the top scene as they are, e.g. typed into a
+// text field or bound to an action, instead of being global actions
+func (sm *SceneManager) capturing() bool {
+	return sdl.IsTextInputActive() || sm.top().scene.Capturing()
+}
+
+// frame updates the top scene by dt and presents the next frame on display. The top scene is
+// updated and rendered while a transition to it is played too. It reports whether the game
+// should quit.
+func (sm *SceneManager) frame(renderer render.Renderer, display render.Display, dt time.Duration) (bool, error) {
+	quit, err := sm.dispatch(renderer, sm.top().scene.Update(dt))
+	if quit || err != nil {
+		return quit, err
+	}
+
+	if err := renderer.Clear(color.NRGBA{A: 255}); err != nil {
+		return false, err
+	}
+	if sm.transitioning {
+		done, err := sm.transition.Paint(renderer, sm)
+		if err != nil {
+			return false, err
+		}
+		sm.transitioning = !done
+	} else if err := sm.Render(renderer); err != nil {
+		return false, err
+	}
+	display.Present()
+
+	return false, nil
+}
+
+// Render renders the top scene of the stack over the scenes it's transparent for. It implements
+// transition.Scene.
+func (sm *SceneManager) Render(renderer render.Renderer) error {
+	bottom := len(sm.stack) - 1
+	for bottom > 0 && sm.stack[bottom].scene.Transparent() {
+		bottom--
+	}
+
+	for _, entry := range sm.stack[bottom:] {
+		if err := entry.scene.Render(renderer); err != nil {
+			return fmt.Errorf("could not render %s scene: %v", entry.name, err)
+		}
+	}
 
-	return errc
+	return nil
+}
+
+// dispatch handles event of the top scene, if any, and starts transition to the scene which is
+// on top afterwards. It reports whether the game should quit.
+func (sm *SceneManager) dispatch(renderer render.Renderer, e scene.Event) (bool, error) {
+	switch event := e.(type) {
+	case nil:
+		return false, nil
+	case *scene.QuitEvent:
+		return true, nil
+	case *scene.ErrorEvent:
+		return false, fmt.Errorf("Error from scene %v", event.Err)
+	}
+
+	cfg := sm.cfg.Transition
+	animate := cfg.Kind != config.TransitionNone && cfg.Duration > 0
+	if animate {
+		if err := sm.transition.Capture(renderer, sm); err != nil {
+			return false, err
+		}
+	}
+
+	if err := sm.handle(e); err != nil {
+		return false, err
+	}
+	if len(sm.stack) == 0 {
+		return true, nil
+	}
+	if !animate {
+		sm.transitioning = false
+		return false, nil
+	}
+
+	duration := time.Duration(cfg.Duration) * time.Millisecond
+	sm.transition.Begin(transition.Kind(cfg.Kind), duration, isBack(e))
+	sm.transitioning = true
+
+	return false, nil
+}
+
+// isBack reports whether event e takes player back to a previous scene, so the transition is
+// played backwards
+func isBack(e scene.Event) bool {
+	switch event := e.(type) {
+	case *scene.PopEvent, *scene.ApplySettingsEvent:
+		return true
+	case *scene.ReplaceEvent:
+		return event.Back
+	}
+	return false
+}
+
+// handle changes the stack according to event of the top scene
+func (sm *SceneManager) handle(e scene.Event) error {
+	switch event := e.(type) {
+	case *scene.PushEvent:
+		return sm.push(event.Scene)
+
+	case *scene.PopEvent:
+		sm.pop()
+
+	case *scene.ReplaceEvent:
+		n := 1
+		if event.All {
+			n = len(sm.stack)
+		}
+		sm.exit(n)
+		return sm.enter(event.Scene)
+
+	case *scene.StartGameEvent:
+		sm.setMode(event.Mode)
+		sm.exit(len(sm.stack))
+		return sm.enter(scene.GameScene)
+
+	case *scene.EndGameEvent:
+		sm.lastGame = event
+		sm.saveBestScore(event.BestScore)
+		// the best score of an unranked game isn't saved, so the stored one is shown and kept
+		sm.game.SetBestScore(sm.saveData.BestScore)
+		sm.gameOver.SetNameEntry(sm.ranked() && sm.saveData.Qualifies(event.Score))
+		sm.gameOver.SetBestScore(sm.saveData.BestScore)
+		sm.gameOver.SetSeed(event.Seed)
+		sm.gameOver.SetReplay(event.Replay)
+		return sm.push(scene.GameOverScene)
+
+	case *scene.SubmitScoreEvent:
+		// the leaderboard is shown over game over, so player can save the replay afterwards
+		sm.leaderboard.SetHighlight(sm.saveScore(event.Name))
+		return sm.push(scene.LeaderboardScene)
+
+	case *scene.ApplySettingsEvent:
+		sm.applySettings(event.Config)
+		sm.pop()
+
+	default:
+		return fmt.Errorf("unknown event %T", e)
+	}
+
+	return nil
+}
+
+func (sm *SceneManager) top() *stackEntry {
+	return sm.stack[len(sm.stack)-1]
+}
+
+// push pauses the top scene and puts scene name above it
+func (sm *SceneManager) push(name string) error {
+	if len(sm.stack) > 0 {
+		sm.top().scene.Pause()
+	}
+	return sm.enter(name)
+}
+
+// pop takes the top scene off the stack and resumes the scene below it
+func (sm *SceneManager) pop() {
+	sm.exit(1)
+	if len(sm.stack) > 0 {
+		sm.top().scene.Resume()
+		sm.playMusic()
+	}
+}
+
+// enter puts scene name on top of the stack, the scene below stays as it is
+func (sm *SceneManager) enter(name string) error {
+	s, ok := sm.scenes[name]
+	if !ok {
+		return fmt.Errorf("unknown scene %q", name)
+	}
+
+	sm.stack = append(sm.stack, &stackEntry{name: name, scene: s})
+	s.Enter()
+	sm.playMusic()
+	return nil
+}
+
+// exit takes n top scenes off the stack
+func (sm *SceneManager) exit(n int) {
+	for ; n > 0 && len(sm.stack) > 0; n-- {
+		sm.top().scene.Exit()
+		sm.stack = sm.stack[:len(sm.stack)-1]
+	}
+}
+
+// playMusic plays music of the top scene
+func (sm *SceneManager) playMusic() {
+	if music := sm.music[sm.top().name]; music != "" {
+		sm.audio.PlayMusic(music)
+	} else {
+		sm.audio.PauseMusic()
+	}
+}
+
+// saveBestScore saves the best score if it's beaten in a ranked game
+func (sm *SceneManager) saveBestScore(bestScore int) {
+	if !sm.ranked() || bestScore <= sm.saveData.BestScore {
+		return
+	}
+
+	sm.saveData.BestScore = bestScore
+	if err := sm.store.Save(sm.saveData); err != nil {
+		log.Printf("could not save best score: %v", err)
+	}
+}
+
+// ranked reports whether scores of games count. Replayed games and games with the seed given by
+// player don't, since their pipes are known in advance. Daily games do count: their seed isn't
+// picked by the player and the same pipes for everyone are the point of the challenge.
+func (sm *SceneManager) ranked() bool {
+	return !sm.replaying && !sm.fixedSeed
+}
+
+// saveScore adds the last game to the leaderboard under the name. It returns position of the
+// game in the leaderboard, or -1 if the game isn't ranked.
+func (sm *SceneManager) saveScore(name string) int {
+	if !sm.ranked() {
+		return -1
+	}
+
+	pos := sm.saveData.AddEntry(save.Entry{
+		Name:  name,
+		Date:  time.Now(),
+		Seed:  sm.lastGame.Seed,
+		Score: sm.lastGame.Score,
+	})
+
+	if err := sm.store.Save(sm.saveData); err != nil {
+		log.Printf("could not save leaderboard: %v", err)
+	}
+
+	return pos
+}
+
+// setMode makes following games be played in mode. Classic games use the seed set with SetSeed
+// if any. Replays keep their own seed.
+func (sm *SceneManager) setMode(mode scene.Mode) {
+	if sm.replaying {
+		return
+	}
+
+	switch mode {
+	case scene.Classic:
+		if sm.fixedSeed {
+			sm.game.SetSeed(sm.seed)
+		} else {
+			sm.game.ResetSeed()
+		}
+	case scene.Daily:
+		sm.game.SetSeed(scene.DailySeed(time.Now()))
+	}
+}
+
+// applySettings applies settings changed by player and saves them to the config file. Settings
+// the game isn't playable with are neither applied nor saved.
+func (sm *SceneManager) applySettings(cfg config.Config) {
+	if err := cfg.Validate(); err != nil {
+		log.Printf("could not apply settings: %v", err)
+		return
+	}
+
+	sm.audio.SetVolume(cfg.Audio.Master, cfg.Audio.Music, cfg.Audio.SFX)
+	sm.audio.SetMuted(cfg.Audio.Muted)
+	sm.game.SetPhysics(cfg.Physics)
+	fullscreen := sm.cfg.Fullscreen
+	sm.cfg = cfg
+
+	if cfg.Fullscreen != fullscreen {
+		// drop the previous request main hasn't handled yet
+		select {
+		case <-sm.windowModes:
+		default:
+		}
+		sm.windowModes <- cfg.Fullscreen
+	}
+
+	// the file is updated with the changed settings only, so values set by flags aren't saved
+	file := config.Default()
+	if err := file.Load(sm.configPath); err != nil && !os.IsNotExist(err) {
+		log.Printf("could not load config: %v", err)
+		return
+	}
+	file.Fullscreen = cfg.Fullscreen
+	file.Theme = cfg.Theme
+	file.Audio = cfg.Audio
+	if file.Difficulty != cfg.Difficulty {
+		if err := file.SetDifficulty(cfg.Difficulty); err != nil {
+			log.Printf("could not save config: %v", err)
+			return
+		}
+	}
+	if err := file.Validate(); err != nil {
+		log.Printf("could not save config: %v", err)
+		return
+	}
+
+	if err := file.Save(sm.configPath); err != nil {
+		log.Printf("could not save config: %v", err)
+	}
+}
+
+// WindowModes returns channel of window modes player has picked in settings, true means
+// fullscreen. The scene manager doesn't own the window, so it's switched by the receiver.
+func (sm *SceneManager) WindowModes() <-chan bool {
+	return sm.windowModes
+}
+
+// SetSeed makes all games use pipes generated from the given seed
+func (sm *SceneManager) SetSeed(seed int64) {
+	sm.seed = seed
+	sm.fixedSeed = true
+	sm.game.SetSeed(seed)
+}
+
+// SetReplay makes all games play back rep
+func (sm *SceneManager) SetReplay(rep *replay.Replay) {
+	sm.replaying = true
+	sm.game.SetReplay(rep)
 }
 
 // Destroy frees all resources of SceneManager
 func (sm *SceneManager) Destroy() {
-	sm.splash.Destroy()
-	sm.game.Destroy()
+	if sm.transition != nil {
+		sm.transition.Destroy()
+	}
+	for _, s := range sm.scenes {
+		s.Destroy()
+	}
 }
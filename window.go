@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spoof/go-flappybird/config"
+	"github.com/spoof/go-flappybird/input"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// createWindow creates resizable window and its renderer. The renderer draws at logical
+// resolution from the config.
+func createWindow(cfg config.Config) (*sdl.Window, *sdl.Renderer, error) {
+	quality := "linear"
+	if cfg.Scaling != config.ScalingSmooth {
+		quality = "nearest"
+	}
+	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, quality)
+
+	flags := uint32(sdl.WINDOW_SHOWN | sdl.WINDOW_RESIZABLE)
+	if cfg.Fullscreen {
+		flags |= sdl.WINDOW_FULLSCREEN_DESKTOP
+	}
+
+	w, err := sdl.CreateWindow("Flappy Bird", sdl.WINDOWPOS_UNDEFINED, sdl.WINDOWPOS_UNDEFINED,
+		int32(cfg.WindowWidth), int32(cfg.WindowHeight), flags)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create window: %v", err)
+	}
+
+	r, err := sdl.CreateRenderer(w, -1, sdl.RENDERER_PRESENTVSYNC)
+	if err != nil {
+		w.Destroy()
+		return nil, nil, fmt.Errorf("could not create renderer: %v", err)
+	}
+
+	return w, r, nil
+}
+
+// fitViewport letterboxes the logical screen into the current size of the window and makes
+// mapper translate pointer positions accordingly
+func fitViewport(w *sdl.Window, r *sdl.Renderer, cfg config.Config, mapper *input.Mapper) error {
+	ww, wh := w.GetSize()
+	if ww <= 0 || wh <= 0 {
+		// minimized window, nothing to fit
+		return nil
+	}
+	lw, lh := int32(cfg.WindowWidth), int32(cfg.WindowHeight)
+	v := input.NewViewport(int32(ww), int32(wh), lw, lh, cfg.Scaling == config.ScalingInteger)
+
+	if err := r.SetScale(v.Scale, v.Scale); err != nil {
+		return fmt.Errorf("could not set scale: %v", err)
+	}
+
+	rect := &sdl.Rect{
+		X: int32(float32(v.X) / v.Scale),
+		Y: int32(float32(v.Y) / v.Scale),
+		W: lw,
+		H: lh,
+	}
+	if err := r.SetViewport(rect); err != nil {
+		return fmt.Errorf("could not set viewport: %v", err)
+	}
+
+	mapper.SetViewport(v)
+	return nil
+}
+
+// toggleFullscreen switches the window between fullscreen and windowed modes
+func toggleFullscreen(w *sdl.Window) error {
+	return setFullscreen(w, w.GetFlags()&sdl.WINDOW_FULLSCREEN_DESKTOP == 0)
+}
+
+// setFullscreen switches the window to fullscreen or windowed mode
+func setFullscreen(w *sdl.Window, fullscreen bool) error {
+	var flags uint32
+	if fullscreen {
+		flags = sdl.WINDOW_FULLSCREEN_DESKTOP
+	}
+
+	return w.SetFullscreen(flags)
+}
+
+// isFullscreenToggle reports whether event is F11 or Alt+Enter press
+func isFullscreenToggle(event sdl.Event) bool {
+	e, ok := event.(*sdl.KeyboardEvent)
+	if !ok || e.Type != sdl.KEYDOWN || e.Repeat != 0 {
+		return false
+	}
+
+	switch e.Keysym.Sym {
+	case sdl.K_F11:
+		return true
+	case sdl.K_RETURN:
+		return e.Keysym.Mod&sdl.KMOD_ALT != 0
+	}
+
+	return false
+}
+
+// isResize reports whether event changes size of the window
+func isResize(event sdl.Event) bool {
+	e, ok := event.(*sdl.WindowEvent)
+	return ok && e.Event == sdl.WINDOWEVENT_SIZE_CHANGED
+}
@@ -0,0 +1,93 @@
+package world
+
+const (
+	jumpBoost    = 100
+	tiltSpeed    = 300
+	tiltMinSpeed = 500
+	maxAngle     = 90
+)
+
+// Bird is a main character of this game
+type Bird struct {
+	X         float64
+	Y         float64
+	Width     int
+	Height    int
+	SpeedY    float64
+	Angle     float64
+	isJumping bool
+
+	prevY     float64
+	prevAngle float64
+
+	startX  int
+	startY  int
+	physics Physics
+}
+
+// NewBird creates new bird with center at x, y
+func NewBird(x, y, width, height int, physics Physics) *Bird {
+	bird := &Bird{startX: x, startY: y, Width: width, Height: height, physics: physics}
+	bird.ResetPosition()
+
+	return bird
+}
+
+// ResetPosition resets position of bird to the start one
+func (b *Bird) ResetPosition() {
+	b.X = float64(b.startX - b.Width/2)
+	b.Y = float64(b.startY - b.Height/2)
+	b.SpeedY = 0
+	b.Angle = 0
+	b.isJumping = false
+	b.savePrevious()
+}
+
+// Jump makes bird jump
+func (b *Bird) Jump() {
+	if b.isJumping {
+		b.SpeedY -= jumpBoost
+		return
+	}
+
+	b.isJumping = true
+	b.Angle = 0
+	b.SpeedY = -b.physics.JumpSpeed
+}
+
+// Fall makes bird fall
+func (b *Bird) Fall() {
+	b.SpeedY = b.physics.FallSpeed
+}
+
+// Move moves bird
+func (b *Bird) Move() {
+	dt := b.physics.dt()
+	b.SpeedY += b.physics.Gravity * dt
+	b.Y += b.SpeedY * dt
+
+	if b.isJumping && b.SpeedY >= 0 {
+		b.isJumping = false
+		b.SpeedY = 0
+	}
+}
+
+// Interpolate returns position and angle of the bird between the previous and the current
+// tick. alpha is in range [0, 1], where 0 is the previous tick.
+func (b *Bird) Interpolate(alpha float64) (y, angle float64) {
+	y = b.prevY + (b.Y-b.prevY)*alpha
+	angle = b.prevAngle + (b.Angle-b.prevAngle)*alpha
+	return y, angle
+}
+
+func (b *Bird) savePrevious() {
+	b.prevY = b.Y
+	b.prevAngle = b.Angle
+}
+
+// tilt turns the bird nose down while it is falling fast
+func (b *Bird) tilt() {
+	if b.SpeedY >= tiltMinSpeed && b.Angle < maxAngle {
+		b.Angle += tiltSpeed * b.physics.dt()
+	}
+}
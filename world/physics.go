@@ -0,0 +1,75 @@
+package world
+
+import (
+	"fmt"
+)
+
+// maxTickRate is the highest tick rate physics can have
+const maxTickRate = 1000
+
+// Physics holds constants which define how the world behaves. Speeds are in pixels per second
+// and accelerations are in pixels per second squared.
+type Physics struct {
+	TickRate             int     `json:"tick_rate"`
+	Gravity              float64 `json:"gravity"`
+	JumpSpeed            float64 `json:"jump_speed"`
+	FallSpeed            float64 `json:"fall_speed"`
+	ScrollSpeed          float64 `json:"scroll_speed"`
+	DistanceBetweenPipes int     `json:"distance_between_pipes"`
+	SpaceBetweenPipes    int     `json:"space_between_pipes"`
+	MinPipeHeight        int     `json:"min_pipe_height"`
+}
+
+// DefaultPhysics returns physics of the classic game
+func DefaultPhysics() Physics {
+	return Physics{
+		TickRate:             100,
+		Gravity:              1000,
+		JumpSpeed:            400,
+		FallSpeed:            1000,
+		ScrollSpeed:          200,
+		DistanceBetweenPipes: 300,
+		SpaceBetweenPipes:    160,
+		MinPipeHeight:        100,
+	}
+}
+
+// dt returns duration of a single tick in seconds
+func (p Physics) dt() float64 {
+	return 1 / float64(p.TickRate)
+}
+
+// Validate checks that the world of given height is playable with the physics
+func (p Physics) Validate(height int) error {
+	if p.TickRate <= 0 || p.TickRate > maxTickRate {
+		return fmt.Errorf("tick rate must be in range 1..%d, got %d", maxTickRate, p.TickRate)
+	}
+
+	positive := []struct {
+		name  string
+		value float64
+	}{
+		{"gravity", p.Gravity},
+		{"jump speed", p.JumpSpeed},
+		{"fall speed", p.FallSpeed},
+		{"scroll speed", p.ScrollSpeed},
+		{"distance between pipes", float64(p.DistanceBetweenPipes)},
+		{"gap between pipes", float64(p.SpaceBetweenPipes)},
+	}
+	for _, v := range positive {
+		if v.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %v", v.name, v.value)
+		}
+	}
+
+	if p.MinPipeHeight < 0 {
+		return fmt.Errorf("minimal pipe height can't be negative, got %d", p.MinPipeHeight)
+	}
+
+	if 2*p.MinPipeHeight+p.SpaceBetweenPipes >= height {
+		return fmt.Errorf("two pipes of minimal height %d and gap %d don't fit height %d",
+			p.MinPipeHeight, p.SpaceBetweenPipes, height)
+	}
+
+	return nil
+}
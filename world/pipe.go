@@ -0,0 +1,78 @@
+package world
+
+import (
+	"math/rand"
+)
+
+// Pipe is a single pipe of a pair
+type Pipe struct {
+	X       float64
+	Y       float64
+	Width   int
+	Height  int
+	IsUpper bool
+}
+
+func (p *Pipe) hits(b *Bird) bool {
+	if p.X < b.X+float64(b.Width) &&
+		p.X+float64(p.Width) > b.X &&
+		p.Y < b.Y+float64(b.Height) &&
+		p.Y+float64(p.Height) > b.Y {
+		return true
+	}
+	return false
+}
+
+// PipePair is a pair of pipes
+type PipePair struct {
+	X       float64
+	Width   int
+	Counted bool
+
+	prevX float64
+
+	Top    *Pipe
+	Bottom *Pipe
+}
+
+// NewPipePair creates new PipePair with given position x and width. Height of pipes is
+// picked using rng.
+func NewPipePair(rng *rand.Rand, x, width, worldHeight int, p Physics) *PipePair {
+	topHeight := random(rng, p.MinPipeHeight, worldHeight-p.MinPipeHeight-p.SpaceBetweenPipes)
+	bottomHeight := worldHeight - topHeight - p.SpaceBetweenPipes
+	bottomY := worldHeight - bottomHeight
+
+	return &PipePair{
+		X:     float64(x),
+		Width: width,
+		prevX: float64(x),
+
+		Top:    &Pipe{X: float64(x), Y: 0, Width: width, Height: topHeight, IsUpper: true},
+		Bottom: &Pipe{X: float64(x), Y: float64(bottomY), Width: width, Height: bottomHeight},
+	}
+}
+
+// Hits checks if bird hits any pipe
+func (pp *PipePair) Hits(b *Bird) bool {
+	if pp.Top.hits(b) || pp.Bottom.hits(b) {
+		return true
+	}
+	return false
+}
+
+// Move moves pipepair by given x
+func (pp *PipePair) Move(x float64) {
+	pp.X += x
+	pp.Top.X += x
+	pp.Bottom.X += x
+}
+
+// Interpolate returns x of the pair between the previous and the current tick. alpha is in
+// range [0, 1], where 0 is the previous tick.
+func (pp *PipePair) Interpolate(alpha float64) float64 {
+	return pp.prevX + (pp.X-pp.prevX)*alpha
+}
+
+func random(rng *rand.Rand, min, max int) int {
+	return rng.Intn(max-min) + min
+}
@@ -0,0 +1,217 @@
+// Package world implements the game simulation. It knows nothing about
+// windows or rendering, so it can be stepped in tests, servers and bots.
+package world
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Config describes dimensions of the world, its objects and physics
+type Config struct {
+	Width      int     `json:"width"`
+	Height     int     `json:"height"`
+	BirdX      int     `json:"bird_x"`
+	BirdWidth  int     `json:"bird_width"`
+	BirdHeight int     `json:"bird_height"`
+	PipeWidth  int     `json:"pipe_width"`
+	Physics    Physics `json:"physics"`
+}
+
+// Validate checks that the world can be simulated with the configuration
+func (c Config) Validate() error {
+	if c.Width <= 0 || c.Height <= 0 {
+		return fmt.Errorf("world size must be positive, got %dx%d", c.Width, c.Height)
+	}
+
+	if c.BirdWidth <= 0 || c.BirdHeight <= 0 || c.PipeWidth <= 0 {
+		return fmt.Errorf("bird size %dx%d and pipe width %d must be positive",
+			c.BirdWidth, c.BirdHeight, c.PipeWidth)
+	}
+
+	if c.BirdX < 0 || c.BirdX+c.BirdWidth > c.Width {
+		return fmt.Errorf("bird x must be inside the world, got %d", c.BirdX)
+	}
+
+	return c.Physics.Validate(c.Height)
+}
+
+// Input is a player input for a single step
+type Input struct {
+	Flap bool
+}
+
+// Events is a set of things which happened during a single step
+type Events uint
+
+// Events reported by Step
+const (
+	// Flapped means the bird has jumped
+	Flapped Events = 1 << iota
+	// Scored means the bird has passed a pipe
+	Scored
+	// Hit means the bird has crashed into a pipe, the ground or the sky
+	Hit
+	// Fell means the crashed bird has fallen to the ground
+	Fell
+)
+
+// Has reports whether e contains any of events
+func (e Events) Has(events Events) bool {
+	return e&events != 0
+}
+
+// World is a state of a single run of the game
+type World struct {
+	cfg Config
+	rng *rand.Rand
+
+	Bird      *Bird
+	PipePairs []*PipePair
+	Score     int
+
+	isGameOver bool
+}
+
+// New creates new World with bird at its start position. Pipes are generated using rng, so
+// worlds created with equally seeded generators and stepped with the same inputs are identical.
+func New(cfg Config, rng *rand.Rand) *World {
+	return &World{
+		cfg:  cfg,
+		rng:  rng,
+		Bird: NewBird(cfg.BirdX, cfg.Height/2, cfg.BirdWidth, cfg.BirdHeight, cfg.Physics),
+	}
+}
+
+// Step advances the world by one tick using given input and reports what has happened during
+// the tick. Duration of the tick is defined by tick rate of the world's physics.
+func (w *World) Step(in Input) Events {
+	var events Events
+	w.savePrevious()
+	wasFinished := w.IsFinished()
+
+	if in.Flap && !w.isGameOver {
+		w.Bird.Jump()
+		events |= Flapped
+	}
+
+	if !w.isGameOver && w.hasCollisions() {
+		w.isGameOver = true
+		events |= Hit
+	}
+
+	if !w.isGameOver {
+		w.generatePipes()
+		w.moveScene()
+		if w.updateScore() {
+			events |= Scored
+		}
+		w.deleteHiddenPipes()
+	} else {
+		w.Bird.Fall()
+	}
+
+	w.moveBird()
+	w.Bird.tilt()
+
+	if !wasFinished && w.IsFinished() {
+		events |= Fell
+	}
+
+	return events
+}
+
+// IsGameOver reports whether the bird has crashed
+func (w *World) IsGameOver() bool {
+	return w.isGameOver
+}
+
+// IsFinished reports whether the bird has crashed and fallen to the ground
+func (w *World) IsFinished() bool {
+	return w.isGameOver && w.doesBirdHitsGround()
+}
+
+func (w *World) savePrevious() {
+	w.Bird.savePrevious()
+	for _, pp := range w.PipePairs {
+		pp.prevX = pp.X
+	}
+}
+
+func (w *World) hasCollisions() bool {
+	if w.Bird.Y <= 0 {
+		return true
+	}
+
+	if w.doesBirdHitsGround() {
+		return true
+	}
+
+	for _, pp := range w.PipePairs {
+		if pp.Hits(w.Bird) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *World) doesBirdHitsGround() bool {
+	if w.Bird.Y+float64(w.Bird.Height) >= float64(w.cfg.Height) {
+		return true
+	}
+
+	return false
+}
+
+func (w *World) generatePipes() {
+	needNewPipe := false
+	if len(w.PipePairs) == 0 {
+		needNewPipe = true
+	} else {
+		lastPipe := w.PipePairs[len(w.PipePairs)-1]
+		if float64(w.cfg.Width)-(lastPipe.X+float64(lastPipe.Width)) >= float64(w.cfg.Physics.DistanceBetweenPipes) {
+			needNewPipe = true
+		}
+	}
+
+	if needNewPipe {
+		pipes := NewPipePair(w.rng, w.cfg.Width, w.cfg.PipeWidth, w.cfg.Height, w.cfg.Physics)
+		w.PipePairs = append(w.PipePairs, pipes)
+	}
+}
+
+func (w *World) moveBird() {
+	if w.Bird.Y+float64(w.Bird.Height) <= float64(w.cfg.Height) {
+		w.Bird.Move()
+	}
+}
+
+func (w *World) moveScene() {
+	for _, pp := range w.PipePairs {
+		pp.Move(-w.cfg.Physics.ScrollSpeed * w.cfg.Physics.dt())
+	}
+}
+
+// updateScore counts pipes passed by the bird. It reports whether the score has changed.
+func (w *World) updateScore() bool {
+	scored := false
+	for _, pp := range w.PipePairs {
+		if !pp.Counted && pp.X+float64(pp.Width) < w.Bird.X {
+			pp.Counted = true
+			w.Score++
+			scored = true
+		}
+	}
+	return scored
+}
+
+func (w *World) deleteHiddenPipes() {
+	pipes := []*PipePair{}
+	for _, pp := range w.PipePairs {
+		if pp.X+float64(pp.Width) >= 0 {
+			pipes = append(pipes, pp)
+		}
+	}
+	w.PipePairs = pipes
+}
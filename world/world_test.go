@@ -0,0 +1,214 @@
+package world_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/spoof/go-flappybird/world"
+	"github.com/spoof/go-flappybird/world/worldtest"
+)
+
+func newWorld(seed int64) *world.World {
+	return world.New(worldtest.Config(), rand.New(rand.NewSource(seed)))
+}
+
+// pipePair returns pair of pipes at x with gap starting at gapY
+func pipePair(cfg world.Config, x float64, gapY int) *world.PipePair {
+	bottomY := gapY + cfg.Physics.SpaceBetweenPipes
+	return &world.PipePair{
+		X:      x,
+		Width:  cfg.PipeWidth,
+		Top:    &world.Pipe{X: x, Y: 0, Width: cfg.PipeWidth, Height: gapY, IsUpper: true},
+		Bottom: &world.Pipe{X: x, Y: float64(bottomY), Width: cfg.PipeWidth, Height: cfg.Height - bottomY},
+	}
+}
+
+func TestWorldStep(t *testing.T) {
+	cfg := worldtest.Config()
+
+	tests := []struct {
+		name   string
+		setup  func(w *world.World)
+		in     world.Input
+		events world.Events
+		check  func(t *testing.T, w *world.World, before world.Bird)
+	}{
+		{
+			name:   "flap",
+			in:     world.Input{Flap: true},
+			events: world.Flapped,
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if w.Bird.SpeedY >= 0 {
+					t.Errorf("bird speed is %v, want negative", w.Bird.SpeedY)
+				}
+				if w.Bird.Y >= before.Y {
+					t.Errorf("bird has moved from %v to %v, want up", before.Y, w.Bird.Y)
+				}
+			},
+		},
+		{
+			name: "gravity",
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if w.Bird.SpeedY <= before.SpeedY {
+					t.Errorf("bird speed has changed from %v to %v, want down", before.SpeedY, w.Bird.SpeedY)
+				}
+			},
+		},
+		{
+			name: "scoring",
+			setup: func(w *world.World) {
+				// the pair touches the bird and passes it during the step
+				w.PipePairs = []*world.PipePair{pipePair(cfg, w.Bird.X-float64(cfg.PipeWidth), 100)}
+			},
+			events: world.Scored,
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if w.Score != 1 {
+					t.Errorf("score is %d, want 1", w.Score)
+				}
+				if !w.PipePairs[0].Counted {
+					t.Errorf("passed pair isn't counted")
+				}
+			},
+		},
+		{
+			name: "counted pair",
+			setup: func(w *world.World) {
+				pp := pipePair(cfg, w.Bird.X-float64(cfg.PipeWidth)-10, 100)
+				pp.Counted = true
+				w.PipePairs = []*world.PipePair{pp}
+				w.Score = 1
+			},
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if w.Score != 1 {
+					t.Errorf("score is %d, want 1", w.Score)
+				}
+			},
+		},
+		{
+			name: "hit pipe",
+			setup: func(w *world.World) {
+				// the gap is below the bird
+				w.PipePairs = []*world.PipePair{pipePair(cfg, w.Bird.X, int(w.Bird.Y)+w.Bird.Height+10)}
+			},
+			events: world.Hit,
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if !w.IsGameOver() {
+					t.Errorf("game isn't over after hit")
+				}
+				if w.IsFinished() {
+					t.Errorf("game is finished before the bird has fallen")
+				}
+			},
+		},
+		{
+			name: "hit sky",
+			setup: func(w *world.World) {
+				w.Bird.Y = 0
+			},
+			events: world.Hit,
+		},
+		{
+			name: "first pipe",
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if len(w.PipePairs) != 1 {
+					t.Fatalf("world has %d pipe pairs, want 1", len(w.PipePairs))
+				}
+				if x := w.PipePairs[0].X; x >= float64(cfg.Width) || x < float64(cfg.Width-cfg.PipeWidth) {
+					t.Errorf("pipe pair is at %v, want at the right edge", x)
+				}
+			},
+		},
+		{
+			name: "next pipe",
+			setup: func(w *world.World) {
+				x := float64(cfg.Width - cfg.PipeWidth - cfg.Physics.DistanceBetweenPipes)
+				w.PipePairs = []*world.PipePair{pipePair(cfg, x, 100)}
+			},
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if len(w.PipePairs) != 2 {
+					t.Errorf("world has %d pipe pairs, want 2", len(w.PipePairs))
+				}
+			},
+		},
+		{
+			name: "pipe recycling",
+			setup: func(w *world.World) {
+				w.PipePairs = []*world.PipePair{
+					pipePair(cfg, -float64(cfg.PipeWidth)+1, 100),
+					pipePair(cfg, float64(cfg.Width-cfg.PipeWidth), 100),
+				}
+				w.PipePairs[0].Counted = true
+			},
+			check: func(t *testing.T, w *world.World, before world.Bird) {
+				if len(w.PipePairs) != 1 {
+					t.Fatalf("world has %d pipe pairs, want 1", len(w.PipePairs))
+				}
+				if w.PipePairs[0].X < 0 {
+					t.Errorf("hidden pipe pair at %v is kept", w.PipePairs[0].X)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWorld(1)
+			if tt.setup != nil {
+				tt.setup(w)
+			}
+
+			before := *w.Bird
+			if events := w.Step(tt.in); events != tt.events {
+				t.Errorf("Step() = %b, want %b", events, tt.events)
+			}
+			if tt.check != nil {
+				tt.check(t, w, before)
+			}
+		})
+	}
+}
+
+func TestWorldFall(t *testing.T) {
+	cfg := worldtest.Config()
+	w := newWorld(1)
+	w.Bird.Y = 0
+	if events := w.Step(world.Input{}); !events.Has(world.Hit) {
+		t.Fatalf("Step() = %b, want hit of the sky", events)
+	}
+
+	var events world.Events
+	for i := 0; i < 1000 && !w.IsFinished(); i++ {
+		events |= w.Step(world.Input{Flap: true})
+	}
+
+	if !w.IsFinished() {
+		t.Fatalf("game isn't finished after the bird has hit the sky")
+	}
+	if events != world.Fell {
+		t.Errorf("steps have reported %b, want fall only, the crashed bird can't flap", events)
+	}
+	if y := w.Bird.Y + float64(w.Bird.Height); y < float64(cfg.Height) {
+		t.Errorf("bird has stopped at %v, want on the ground at %v", y, cfg.Height)
+	}
+}
+
+func TestWorldDeterminism(t *testing.T) {
+	run := func(seed int64) *world.World {
+		w := newWorld(seed)
+		for i := 0; i < 2000 && !w.IsFinished(); i++ {
+			w.Step(world.Input{Flap: i%28 == 0})
+		}
+		return w
+	}
+
+	a, b := run(7), run(7)
+	if !reflect.DeepEqual(a.Bird, b.Bird) || !reflect.DeepEqual(a.PipePairs, b.PipePairs) || a.Score != b.Score {
+		t.Errorf("worlds of the same seed differ")
+	}
+
+	c := run(8)
+	if reflect.DeepEqual(a.PipePairs, c.PipePairs) {
+		t.Errorf("worlds of different seeds have the same pipes")
+	}
+}
@@ -0,0 +1,18 @@
+// Package worldtest provides helpers for tests which step worlds
+package worldtest
+
+import "github.com/spoof/go-flappybird/world"
+
+// Config returns config of an 800x600 world with bird and pipes of the original sprites
+// and the classic physics
+func Config() world.Config {
+	return world.Config{
+		Width:      800,
+		Height:     600,
+		BirdX:      200,
+		BirdWidth:  50,
+		BirdHeight: 40,
+		PipeWidth:  80,
+		Physics:    world.DefaultPhysics(),
+	}
+}